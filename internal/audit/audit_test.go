@@ -0,0 +1,260 @@
+package audit
+
+import (
+	"errors"
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+)
+
+// chain links events into entries 1..n as Append would.
+func chain(t *testing.T, events ...Event) []*Entry {
+	t.Helper()
+
+	at := time.Date(2026, 1, 2, 3, 4, 5, 123456000, time.UTC)
+	prev := genesis
+	var out []*Entry
+	for i, ev := range events {
+		e := &Entry{
+			Event:    ev,
+			ID:       int64(i + 1),
+			At:       at.Add(time.Duration(i) * time.Second),
+			Service:  "auth",
+			PrevHash: prev,
+		}
+		sum, err := e.sum()
+		if err != nil {
+			t.Fatal(err)
+		}
+		e.Hash = sum
+		prev = sum
+		out = append(out, e)
+	}
+
+	return out
+}
+
+// seq yields entries as Verify yields rows.
+func seq(entries []*Entry) iter.Seq2[*Entry, error] {
+	return func(yield func(*Entry, error) bool) {
+		for _, e := range entries {
+			if !yield(e, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestVerifyChain(t *testing.T) {
+	events := []Event{
+		{ActorID: 1, Action: "auth.signup", Target: "user:1", IP: "10.0.0.1", Details: map[string]string{}},
+		{ActorID: 1, Action: "auth.login", Target: "user:1", IP: "10.0.0.1", Details: map[string]string{"method": "password"}},
+		{ActorID: 0, Action: "auth.login_failed", IP: "10.0.0.2", Details: map[string]string{"email": "a@example.com"}},
+		{ActorID: 1, Action: "auth.logout", Target: "user:1", Details: map[string]string{}},
+	}
+
+	tests := []struct {
+		name     string
+		tamper   func([]*Entry) []*Entry
+		checked  int64
+		brokenAt int64 // 0 if the chain holds
+	}{
+		{
+			name:    "intact",
+			tamper:  func(es []*Entry) []*Entry { return es },
+			checked: 4,
+		},
+		{
+			name: "detail changed",
+			tamper: func(es []*Entry) []*Entry {
+				es[1].Details["method"] = "oidc"
+				return es
+			},
+			checked:  1,
+			brokenAt: 2,
+		},
+		{
+			name: "actor changed",
+			tamper: func(es []*Entry) []*Entry {
+				es[2].ActorID = 7
+				return es
+			},
+			checked:  2,
+			brokenAt: 3,
+		},
+		{
+			name: "time changed",
+			tamper: func(es []*Entry) []*Entry {
+				es[0].At = es[0].At.Add(time.Microsecond)
+				return es
+			},
+			brokenAt: 1,
+		},
+		{
+			name: "changed and rehashed",
+			tamper: func(es []*Entry) []*Entry {
+				es[1].Action = "auth.logout"
+				es[1].Hash, _ = es[1].sum()
+				return es
+			},
+			checked:  2,
+			brokenAt: 3,
+		},
+		{
+			name:     "entry removed",
+			tamper:   func(es []*Entry) []*Entry { return slices.Delete(es, 1, 2) },
+			checked:  1,
+			brokenAt: 3,
+		},
+		{
+			name: "entries swapped",
+			tamper: func(es []*Entry) []*Entry {
+				es[1], es[2] = es[2], es[1]
+				return es
+			},
+			checked:  1,
+			brokenAt: 3,
+		},
+		{
+			name:     "first entry removed",
+			tamper:   func(es []*Entry) []*Entry { return es[1:] },
+			brokenAt: 2,
+		},
+		{
+			name: "new genesis",
+			tamper: func(es []*Entry) []*Entry {
+				es[0].PrevHash = "f" + genesis[1:]
+				return es
+			},
+			brokenAt: 1,
+		},
+		{
+			// Documented: only an exported head hash catches this.
+			name:    "last entry removed",
+			tamper:  func(es []*Entry) []*Entry { return es[:3] },
+			checked: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := VerifyChain(seq(tt.tamper(chain(t, copyEvents(events)...))))
+			if n != tt.checked {
+				t.Errorf("checked %d entries, want %d", n, tt.checked)
+			}
+
+			if tt.brokenAt == 0 {
+				if err != nil {
+					t.Fatalf("error = %v, want nil", err)
+				}
+				return
+			}
+			var broken *BrokenChainError
+			if !errors.As(err, &broken) {
+				t.Fatalf("error = %v, want a *BrokenChainError", err)
+			}
+			if broken.ID != tt.brokenAt {
+				t.Errorf("broken at entry %d, want %d", broken.ID, tt.brokenAt)
+			}
+		})
+	}
+}
+
+// copyEvents copies events deeply enough for a test case to alter Details.
+func copyEvents(events []Event) []Event {
+	out := make([]Event, len(events))
+	for i, ev := range events {
+		ev.Details = maps.Clone(ev.Details)
+		out[i] = ev
+	}
+
+	return out
+}
+
+func TestSumIgnoresMapOrder(t *testing.T) {
+	a := &Entry{PrevHash: genesis, Event: Event{Details: map[string]string{}}}
+	b := &Entry{PrevHash: genesis, Event: Event{Details: map[string]string{}}}
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		a.Details[k] = k
+	}
+	for _, k := range []string{"e", "d", "c", "b", "a"} {
+		b.Details[k] = k
+	}
+
+	sa, err := a.sum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sb, err := b.sum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sa != sb {
+		t.Errorf("sums differ with the same details: %s, %s", sa, sb)
+	}
+}
+
+func TestVerifyChainReadError(t *testing.T) {
+	es := chain(t, Event{Action: "auth.login", Details: map[string]string{}}, Event{Action: "auth.logout", Details: map[string]string{}})
+	errRead := errors.New("connection reset")
+
+	n, err := VerifyChain(func(yield func(*Entry, error) bool) {
+		if yield(es[0], nil) {
+			yield(nil, errRead)
+		}
+	})
+	if n != 1 || !errors.Is(err, errRead) {
+		t.Fatalf("VerifyChain = %d, %v; want 1, %v", n, err, errRead)
+	}
+}
+
+func TestStorable(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Event
+		want Event
+	}{
+		{
+			name: "nil details",
+			in:   Event{Action: "auth.logout"},
+			want: Event{Action: "auth.logout", Details: map[string]string{}},
+		},
+		{
+			name: "unchanged",
+			in:   Event{Action: "auth.login", Target: "user:1", IP: "::1", Details: map[string]string{"method": "password"}},
+			want: Event{Action: "auth.login", Target: "user:1", IP: "::1", Details: map[string]string{"method": "password"}},
+		},
+		{
+			name: "NUL in details",
+			in:   Event{Action: "auth.login_failed", Details: map[string]string{"email": "a\x00@example.com", "ua\x00": "x"}},
+			want: Event{Action: "auth.login_failed", Details: map[string]string{"email": "a@example.com", "ua": "x"}},
+		},
+		{
+			name: "NUL elsewhere",
+			in:   Event{Action: "auth.\x00login", Target: "user:\x001", IP: "\x00", Details: map[string]string{}},
+			want: Event{Action: "auth.login", Target: "user:1", IP: "", Details: map[string]string{}},
+		},
+		{
+			name: "invalid UTF-8",
+			in:   Event{Action: "auth.login_failed", Details: map[string]string{"email": "a\xff@example.com"}},
+			want: Event{Action: "auth.login_failed", Details: map[string]string{"email": "a\uFFFD@example.com"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := maps.Clone(tt.in.Details)
+			got := tt.in.storable()
+
+			if got.Action != tt.want.Action || got.Target != tt.want.Target || got.IP != tt.want.IP ||
+				!maps.Equal(got.Details, tt.want.Details) {
+				t.Errorf("storable() = %+q, want %+q", got, tt.want)
+			}
+			if !maps.Equal(tt.in.Details, orig) {
+				t.Errorf("storable() changed the caller's details to %q", tt.in.Details)
+			}
+		})
+	}
+}
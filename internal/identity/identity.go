@@ -0,0 +1,204 @@
+// Package identity carries the end user a request is made for from the
+// gateway to the backend services, so that each backend can check requests
+// against the user itself instead of trusting IDs in the request body.
+//
+// The gateway verifies the user's token, then signs their ID, and their email
+// once verified, into the "x-godrive-user" metadata with a key shared by the
+// services (HMAC-SHA256).
+// Signatures are short-lived; a backend calling another backend on the
+// user's behalf forwards the metadata as is (see Forward).
+package identity
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// mdKey is the metadata key holding "<uid>.<expiry unix>.<signature>", or
+// "<uid>.<expiry unix>.<base64 email>.<signature>" for a verified email.
+const mdKey = "x-godrive-user"
+
+// ttl is how long a signed identity is accepted. It only has to outlive one
+// request's fan-out between services.
+const ttl = time.Minute
+
+// Signer signs and verifies identities with a shared key.
+type Signer struct {
+	key []byte
+}
+
+// New returns a Signer for key, which must be at least 32 bytes.
+func New(key []byte) (*Signer, error) {
+	if len(key) < 32 {
+		return nil, errors.New("identity key must be at least 32 bytes")
+	}
+
+	return &Signer{key: key}, nil
+}
+
+// FromEnv returns a Signer for the key in IDENTITY_KEY. There is no default:
+// a guessable key would let anyone who can reach a backend act as any user.
+func FromEnv() (*Signer, error) {
+	s, err := New([]byte(os.Getenv("IDENTITY_KEY")))
+	if err != nil {
+		return nil, fmt.Errorf("IDENTITY_KEY: %w", err)
+	}
+
+	return s, nil
+}
+
+func (s *Signer) sign(payload string) string {
+	m := hmac.New(sha256.New, s.key)
+	m.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(m.Sum(nil))
+}
+
+// Outgoing returns ctx with uid signed into its outgoing metadata.
+func (s *Signer) Outgoing(ctx context.Context, uid int64) context.Context {
+	return s.OutgoingVerified(ctx, uid, "")
+}
+
+// OutgoingVerified is Outgoing for a user whose email is verified, which
+// backends may then rely on (e.g. files, for what was shared with it). An
+// empty email is the same as Outgoing.
+func (s *Signer) OutgoingVerified(ctx context.Context, uid int64, email string) context.Context {
+	payload := fmt.Sprintf("%d.%d", uid, time.Now().Add(ttl).Unix())
+	if email != "" {
+		payload += "." + base64.RawURLEncoding.EncodeToString([]byte(email))
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, mdKey, payload+"."+s.sign(payload))
+}
+
+// who is a verified identity.
+type who struct {
+	uid   int64
+	email string // verified email, if any
+}
+
+// verify checks a signed identity and returns who it is for.
+func (s *Signer) verify(v string) (who, error) {
+	i := strings.LastIndex(v, ".")
+	if i < 0 {
+		return who{}, errors.New("malformed identity")
+	}
+	payload, sig := v[:i], v[i+1:]
+	if !hmac.Equal([]byte(sig), []byte(s.sign(payload))) {
+		return who{}, errors.New("bad identity signature")
+	}
+
+	parts := strings.Split(payload, ".")
+	if len(parts) != 2 && len(parts) != 3 {
+		return who{}, errors.New("malformed identity")
+	}
+	uid, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || uid <= 0 {
+		return who{}, errors.New("malformed identity")
+	}
+	exp, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return who{}, errors.New("malformed identity")
+	}
+	if time.Now().Unix() > exp {
+		return who{}, errors.New("identity expired")
+	}
+
+	w := who{uid: uid}
+	if len(parts) == 3 {
+		email, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil || len(email) == 0 {
+			return who{}, errors.New("malformed identity")
+		}
+		w.email = string(email)
+	}
+
+	return w, nil
+}
+
+// Forward copies the caller's signed identity to ctx's outgoing metadata,
+// for calling another service on the same user's behalf.
+func Forward(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(mdKey); len(v) > 0 {
+		return metadata.AppendToOutgoingContext(ctx, mdKey, v[0])
+	}
+
+	return ctx
+}
+
+type ctxKey struct{}
+
+// User returns the user ID the interceptor verified for this call, if any.
+func User(ctx context.Context) (int64, bool) {
+	w, ok := ctx.Value(ctxKey{}).(who)
+	return w.uid, ok
+}
+
+// VerifiedEmail returns the user's email if the gateway signed it in as
+// verified, or "".
+func VerifiedEmail(ctx context.Context) string {
+	w, _ := ctx.Value(ctxKey{}).(who)
+	return w.email
+}
+
+// Check decides whether user uid may make request req. Its error is returned
+// to the client as is.
+type Check func(uid int64, req any) error
+
+// Policy maps full gRPC method names to the check for them. Methods in the
+// policy need a signed identity; methods not in it are service-to-service
+// calls that act for no particular user and don't.
+type Policy map[string]Check
+
+// Owns returns a Check that the request's owner (as returned by owner, e.g.
+// (*gv1.ListFilesRequest).GetOwnerId) is the user making it.
+func Owns[T any](owner func(T) int64) Check {
+	return func(uid int64, req any) error {
+		r, ok := req.(T)
+		if !ok || owner(r) != uid {
+			return status.Error(codes.PermissionDenied, "request is not for the calling user")
+		}
+
+		return nil
+	}
+}
+
+// UnaryServerInterceptor verifies the signed identity on calls to methods in
+// p and runs their check. The user ID is available to handlers via User.
+func (s *Signer) UnaryServerInterceptor(p Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		check, ok := p[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		v := md.Get(mdKey)
+		if len(v) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "missing user identity")
+		}
+		w, err := s.verify(v[0])
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if err := check(w.uid, req); err != nil {
+			return nil, err
+		}
+
+		return handler(context.WithValue(ctx, ctxKey{}, w), req)
+	}
+}
@@ -0,0 +1,82 @@
+package identity
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func testSigner(t *testing.T, key string) *Signer {
+	t.Helper()
+
+	s, err := New([]byte(strings.Repeat(key, 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+// signed is the identity OutgoingVerified puts in the metadata.
+func signed(t *testing.T, s *Signer, uid int64, email string) string {
+	t.Helper()
+
+	md, _ := metadata.FromOutgoingContext(s.OutgoingVerified(context.Background(), uid, email))
+	v := md.Get(mdKey)
+	if len(v) != 1 {
+		t.Fatalf("metadata %q = %q, want one value", mdKey, v)
+	}
+
+	return v[0]
+}
+
+func TestVerify(t *testing.T) {
+	s := testSigner(t, "k")
+	other := testSigner(t, "o")
+	exp := time.Now().Add(ttl).Unix()
+	email := base64.RawURLEncoding.EncodeToString([]byte("a@example.com"))
+
+	// withSig signs a hand-made payload.
+	withSig := func(payload string) string { return payload + "." + s.sign(payload) }
+
+	tests := []struct {
+		name    string
+		v       string
+		want    who
+		wantErr bool
+	}{
+		{name: "user", v: signed(t, s, 42, ""), want: who{uid: 42}},
+		{name: "verified email", v: signed(t, s, 42, "a@example.com"), want: who{uid: 42, email: "a@example.com"}},
+		{name: "other key", v: signed(t, other, 42, ""), wantErr: true},
+		{name: "bad signature", v: signed(t, s, 42, "")[:20] + "x", wantErr: true},
+		{name: "uid changed", v: "43" + signed(t, s, 42, "")[2:], wantErr: true},
+		{name: "email added", v: strings.Replace(signed(t, s, 42, ""), ".", "."+email+".", 1), wantErr: true},
+		{name: "no signature", v: "42", wantErr: true},
+		{name: "expired", v: withSig(fmt.Sprintf("42.%d", time.Now().Add(-time.Second).Unix())), wantErr: true},
+		{name: "zero uid", v: withSig(fmt.Sprintf("0.%d", exp)), wantErr: true},
+		{name: "bad uid", v: withSig(fmt.Sprintf("x.%d", exp)), wantErr: true},
+		{name: "empty email", v: withSig(fmt.Sprintf("42.%d.", exp)), wantErr: true},
+		{name: "bad email", v: withSig(fmt.Sprintf("42.%d.!!", exp)), wantErr: true},
+		{name: "extra part", v: withSig(fmt.Sprintf("42.%d.%s.x", exp, email)), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.verify(tt.v)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("verify(%q) = %+v, want an error", tt.v, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("verify(%q) = %+v, %v; want %+v", tt.v, got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package mtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// fromService is a call context whose verified client certificate is leaf.
+func fromService(leaf *x509.Certificate) context.Context {
+	info := credentials.TLSInfo{State: tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{leaf}},
+	}}
+
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4242},
+		AuthInfo: info,
+	})
+}
+
+func named(dns string) *x509.Certificate {
+	return &x509.Certificate{DNSNames: []string{dns}}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	files := grpc.ServiceDesc{ServiceName: "godrive.v1.FilesService"}
+	p := Policy{
+		AllMethods(files):                        {"gateway"},
+		"/godrive.v1.FilesService/ConfirmUpload": {"ingest"},
+		"/godrive.v1.FilesService/UsageOf":       {"gateway", "auth"},
+	}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   codes.Code
+	}{
+		{"service-wide entry", fromService(named("gateway")), "/godrive.v1.FilesService/ListChildren", codes.OK},
+		{"not in service-wide entry", fromService(named("janitor")), "/godrive.v1.FilesService/ListChildren", codes.PermissionDenied},
+		{"exact entry", fromService(named("ingest")), "/godrive.v1.FilesService/ConfirmUpload", codes.OK},
+		{"exact entry wins", fromService(named("gateway")), "/godrive.v1.FilesService/ConfirmUpload", codes.PermissionDenied},
+		{"exact entry, second caller", fromService(named("auth")), "/godrive.v1.FilesService/UsageOf", codes.OK},
+		{"exact entry only for its method", fromService(named("ingest")), "/godrive.v1.FilesService/ListChildren", codes.PermissionDenied},
+		{"unknown service", fromService(named("gateway")), "/godrive.v1.StorageService/DeleteObject", codes.PermissionDenied},
+		{"unknown method", fromService(named("gateway")), "/grpc.health.v1.Health/Check", codes.PermissionDenied},
+		{"no method", fromService(named("gateway")), "", codes.PermissionDenied},
+		{"case matters", fromService(named("Gateway")), "/godrive.v1.FilesService/ListChildren", codes.PermissionDenied},
+		{"first DNS name only", fromService(&x509.Certificate{DNSNames: []string{"janitor", "gateway"}}), "/godrive.v1.FilesService/ListChildren", codes.PermissionDenied},
+		{"common name", fromService(&x509.Certificate{Subject: pkix.Name{CommonName: "gateway"}}), "/godrive.v1.FilesService/ListChildren", codes.OK},
+		{"wrong common name", fromService(&x509.Certificate{Subject: pkix.Name{CommonName: "storage"}}), "/godrive.v1.FilesService/ListChildren", codes.PermissionDenied},
+		{"nameless certificate", fromService(&x509.Certificate{}), "/godrive.v1.FilesService/ListChildren", codes.Unauthenticated},
+		{"no peer", context.Background(), "/godrive.v1.FilesService/ListChildren", codes.Unauthenticated},
+		{"no TLS", peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{}}), "/godrive.v1.FilesService/ListChildren", codes.Unauthenticated},
+		{"unverified chain", peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{}, AuthInfo: credentials.TLSInfo{}}), "/godrive.v1.FilesService/ListChildren", codes.Unauthenticated},
+	}
+
+	intercept := UnaryServerInterceptor(p)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			_, err := intercept(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method},
+				func(context.Context, any) (any, error) {
+					called = true
+					return nil, nil
+				})
+
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("code = %s, want %s (err %v)", got, tt.want, err)
+			}
+			if called != (tt.want == codes.OK) {
+				t.Errorf("handler called = %v, want %v", called, tt.want == codes.OK)
+			}
+		})
+	}
+}
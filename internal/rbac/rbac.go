@@ -0,0 +1,74 @@
+// Package rbac holds GoDrive's user roles and a gRPC interceptor that
+// enforces which roles may call which methods.
+package rbac
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Role is a user's role, stored in users.role and carried in the "role" token claim.
+type Role string
+
+const (
+	User    Role = "user"    // default: manages their own files
+	Admin   Role = "admin"   // may manage other users
+	Auditor Role = "auditor" // read-only access to admin views
+)
+
+// Valid reports whether r is a known role.
+func (r Role) Valid() bool {
+	return r == User || r == Admin || r == Auditor
+}
+
+// Parse maps a claim or column value to a Role. Tokens minted before roles
+// existed have no role claim and are treated as plain users.
+func Parse(s string) Role {
+	if s == "" {
+		return User
+	}
+
+	return Role(s)
+}
+
+// Policy maps full gRPC method names (e.g. gv1.AuthService_SetUserRole_FullMethodName)
+// to the roles allowed to call them. Methods not listed are not restricted
+// by role; their handlers authenticate callers themselves where needed.
+type Policy map[string][]Role
+
+// Resolver authenticates the caller of an RPC and returns their role.
+// Its error is returned to the client as is.
+type Resolver func(ctx context.Context) (Role, error)
+
+type ctxKey struct{}
+
+// FromContext returns the role the interceptor resolved for this call, if any.
+func FromContext(ctx context.Context) (Role, bool) {
+	r, ok := ctx.Value(ctxKey{}).(Role)
+	return r, ok
+}
+
+// UnaryServerInterceptor rejects calls to methods in p unless the caller's
+// role is allowed. The resolved role is available to handlers via FromContext.
+func UnaryServerInterceptor(p Policy, resolve Resolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		allowed, ok := p[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		role, err := resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(allowed, role) {
+			return nil, status.Errorf(codes.PermissionDenied, "role %q may not call %s", role, info.FullMethod)
+		}
+
+		return handler(context.WithValue(ctx, ctxKey{}, role), req)
+	}
+}
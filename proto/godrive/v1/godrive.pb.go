@@ -63,6 +63,15 @@ type User struct {
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
 	CreatedAt     string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	EmailVerified bool                   `protobuf:"varint,4,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"`
+	// Scopes granted to the credential that was verified. Empty for interactive
+	// sessions, which may do everything; set for personal access tokens.
+	Scopes []string `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// "user", "admin" or "auditor".
+	Role string `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	// Set when the token is scoped to an organization (see OrgService.IssueOrgToken).
+	OrgId         int64  `protobuf:"varint,7,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	OrgRole       string `protobuf:"bytes,8,opt,name=org_role,json=orgRole,proto3" json:"org_role,omitempty"` // "owner", "admin" or "member"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -118,6 +127,41 @@ func (x *User) GetCreatedAt() string {
 	return ""
 }
 
+func (x *User) GetEmailVerified() bool {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return false
+}
+
+func (x *User) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *User) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *User) GetOrgId() int64 {
+	if x != nil {
+		return x.OrgId
+	}
+	return 0
+}
+
+func (x *User) GetOrgRole() string {
+	if x != nil {
+		return x.OrgRole
+	}
+	return ""
+}
+
 type Credentials struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
@@ -170,17 +214,84 @@ func (x *Credentials) GetPassword() string {
 	return ""
 }
 
-type Token struct {
+// SignUpRequest is Credentials plus what the signup mode may ask for.
+type SignUpRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	InviteCode    string                 `protobuf:"bytes,3,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"` // required when SIGNUP_MODE=invite
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignUpRequest) Reset() {
+	*x = SignUpRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignUpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignUpRequest) ProtoMessage() {}
+
+func (x *SignUpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignUpRequest.ProtoReflect.Descriptor instead.
+func (*SignUpRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SignUpRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *SignUpRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *SignUpRequest) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+type Token struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken      string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	ExpiresAt        string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	RefreshToken     string                 `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	RefreshExpiresAt string                 `protobuf:"bytes,4,opt,name=refresh_expires_at,json=refreshExpiresAt,proto3" json:"refresh_expires_at,omitempty"`
+	// Set instead of the tokens above when the password was right but a second
+	// factor is still needed; pass mfa_token to CompleteMFALogin.
+	MfaRequired   bool   `protobuf:"varint,5,opt,name=mfa_required,json=mfaRequired,proto3" json:"mfa_required,omitempty"`
+	MfaToken      string `protobuf:"bytes,6,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Token) Reset() {
 	*x = Token{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[3]
+	mi := &file_godrive_v1_godrive_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -192,7 +303,7 @@ func (x *Token) String() string {
 func (*Token) ProtoMessage() {}
 
 func (x *Token) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[3]
+	mi := &file_godrive_v1_godrive_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -205,7 +316,7 @@ func (x *Token) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Token.ProtoReflect.Descriptor instead.
 func (*Token) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{3}
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Token) GetAccessToken() string {
@@ -222,35 +333,56 @@ func (x *Token) GetExpiresAt() string {
 	return ""
 }
 
-// ===== Files (metadata only, not bytes) =====
-type FileItem struct {
+func (x *Token) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *Token) GetRefreshExpiresAt() string {
+	if x != nil {
+		return x.RefreshExpiresAt
+	}
+	return ""
+}
+
+func (x *Token) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *Token) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+type RefreshRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	OwnerId       int64                  `protobuf:"varint,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Mime          string                 `protobuf:"bytes,4,opt,name=mime,proto3" json:"mime,omitempty"`
-	SizeBytes     int64                  `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	VersionId     string                 `protobuf:"bytes,7,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileItem) Reset() {
-	*x = FileItem{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[4]
+func (x *RefreshRequest) Reset() {
+	*x = RefreshRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileItem) String() string {
+func (x *RefreshRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileItem) ProtoMessage() {}
+func (*RefreshRequest) ProtoMessage() {}
 
-func (x *FileItem) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[4]
+func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -261,84 +393,136 @@ func (x *FileItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileItem.ProtoReflect.Descriptor instead.
-func (*FileItem) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use RefreshRequest.ProtoReflect.Descriptor instead.
+func (*RefreshRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *FileItem) GetId() int64 {
+func (x *RefreshRequest) GetRefreshToken() string {
 	if x != nil {
-		return x.Id
+		return x.RefreshToken
 	}
-	return 0
+	return ""
 }
 
-func (x *FileItem) GetOwnerId() int64 {
-	if x != nil {
-		return x.OwnerId
-	}
-	return 0
+type PasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileItem) GetName() string {
+func (x *PasswordResetRequest) Reset() {
+	*x = PasswordResetRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasswordResetRequest) ProtoMessage() {}
+
+func (x *PasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[6]
 	if x != nil {
-		return x.Name
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *FileItem) GetMime() string {
+// Deprecated: Use PasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*PasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PasswordResetRequest) GetEmail() string {
 	if x != nil {
-		return x.Mime
+		return x.Email
 	}
 	return ""
 }
 
-func (x *FileItem) GetSizeBytes() int64 {
+type ResetPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[7]
 	if x != nil {
-		return x.SizeBytes
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *FileItem) GetCreatedAt() string {
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ResetPasswordRequest) GetToken() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Token
 	}
 	return ""
 }
 
-func (x *FileItem) GetVersionId() string {
+func (x *ResetPasswordRequest) GetNewPassword() string {
 	if x != nil {
-		return x.VersionId
+		return x.NewPassword
 	}
 	return ""
 }
 
-type ListFilesRequest struct {
+type VerifyEmailRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFilesRequest) Reset() {
-	*x = ListFilesRequest{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[5]
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFilesRequest) String() string {
+func (x *VerifyEmailRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFilesRequest) ProtoMessage() {}
+func (*VerifyEmailRequest) ProtoMessage() {}
 
-func (x *ListFilesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[5]
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -349,55 +533,4276 @@ func (x *ListFilesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFilesRequest.ProtoReflect.Descriptor instead.
-func (*ListFilesRequest) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListFilesRequest) GetOwnerId() int64 {
+func (x *VerifyEmailRequest) GetToken() string {
 	if x != nil {
-		return x.OwnerId
+		return x.Token
 	}
-	return 0
+	return ""
 }
 
-func (x *ListFilesRequest) GetPage() int32 {
+type ResendVerificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendVerificationRequest) Reset() {
+	*x = ResendVerificationRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendVerificationRequest) ProtoMessage() {}
+
+func (x *ResendVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[9]
 	if x != nil {
-		return x.Page
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *ListFilesRequest) GetPageSize() int32 {
+// Deprecated: Use ResendVerificationRequest.ProtoReflect.Descriptor instead.
+func (*ResendVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ResendVerificationRequest) GetEmail() string {
 	if x != nil {
-		return x.PageSize
+		return x.Email
 	}
-	return 0
+	return ""
+}
+
+type MagicLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MagicLinkRequest) Reset() {
+	*x = MagicLinkRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MagicLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MagicLinkRequest) ProtoMessage() {}
+
+func (x *MagicLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MagicLinkRequest.ProtoReflect.Descriptor instead.
+func (*MagicLinkRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MagicLinkRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type MagicLinkLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MagicLinkLoginRequest) Reset() {
+	*x = MagicLinkLoginRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MagicLinkLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MagicLinkLoginRequest) ProtoMessage() {}
+
+func (x *MagicLinkLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MagicLinkLoginRequest.ProtoReflect.Descriptor instead.
+func (*MagicLinkLoginRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MagicLinkLoginRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type TOTPEnrollment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	OtpauthUrl    string                 `protobuf:"bytes,2,opt,name=otpauth_url,json=otpauthUrl,proto3" json:"otpauth_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TOTPEnrollment) Reset() {
+	*x = TOTPEnrollment{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TOTPEnrollment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TOTPEnrollment) ProtoMessage() {}
+
+func (x *TOTPEnrollment) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TOTPEnrollment.ProtoReflect.Descriptor instead.
+func (*TOTPEnrollment) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TOTPEnrollment) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *TOTPEnrollment) GetOtpauthUrl() string {
+	if x != nil {
+		return x.OtpauthUrl
+	}
+	return ""
+}
+
+type TOTPCode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TOTPCode) Reset() {
+	*x = TOTPCode{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TOTPCode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TOTPCode) ProtoMessage() {}
+
+func (x *TOTPCode) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TOTPCode.ProtoReflect.Descriptor instead.
+func (*TOTPCode) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TOTPCode) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type RecoveryCodes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Codes         []string               `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoveryCodes) Reset() {
+	*x = RecoveryCodes{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoveryCodes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryCodes) ProtoMessage() {}
+
+func (x *RecoveryCodes) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryCodes.ProtoReflect.Descriptor instead.
+func (*RecoveryCodes) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RecoveryCodes) GetCodes() []string {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+type MFALoginRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MfaToken string                 `protobuf:"bytes,1,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	// A current TOTP code or one of the user's unused recovery codes.
+	Code          string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MFALoginRequest) Reset() {
+	*x = MFALoginRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MFALoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MFALoginRequest) ProtoMessage() {}
+
+func (x *MFALoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MFALoginRequest.ProtoReflect.Descriptor instead.
+func (*MFALoginRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MFALoginRequest) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+func (x *MFALoginRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ResetMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetMFARequest) Reset() {
+	*x = ResetMFARequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetMFARequest) ProtoMessage() {}
+
+func (x *ResetMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetMFARequest.ProtoReflect.Descriptor instead.
+func (*ResetMFARequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ResetMFARequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type SetUserRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserRoleRequest) Reset() {
+	*x = SetUserRoleRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserRoleRequest) ProtoMessage() {}
+
+func (x *SetUserRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetUserRoleRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetUserRoleRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetUserRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type OIDCAuthURL struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Url   string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Echoed back by the IdP on the callback; the gateway binds it to the browser.
+	State         string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OIDCAuthURL) Reset() {
+	*x = OIDCAuthURL{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OIDCAuthURL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OIDCAuthURL) ProtoMessage() {}
+
+func (x *OIDCAuthURL) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OIDCAuthURL.ProtoReflect.Descriptor instead.
+func (*OIDCAuthURL) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *OIDCAuthURL) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *OIDCAuthURL) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type OIDCCallback struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OIDCCallback) Reset() {
+	*x = OIDCCallback{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OIDCCallback) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OIDCCallback) ProtoMessage() {}
+
+func (x *OIDCCallback) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OIDCCallback.ProtoReflect.Descriptor instead.
+func (*OIDCCallback) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OIDCCallback) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *OIDCCallback) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type AccessTokenInfo struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// First characters of the token, so users can tell tokens apart.
+	Prefix        string `protobuf:"bytes,4,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	CreatedAt     string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     string `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`      // empty = never
+	LastUsedAt    string `protobuf:"bytes,7,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"` // empty = never used
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessTokenInfo) Reset() {
+	*x = AccessTokenInfo{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessTokenInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessTokenInfo) ProtoMessage() {}
+
+func (x *AccessTokenInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessTokenInfo.ProtoReflect.Descriptor instead.
+func (*AccessTokenInfo) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AccessTokenInfo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AccessTokenInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AccessTokenInfo) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *AccessTokenInfo) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *AccessTokenInfo) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *AccessTokenInfo) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *AccessTokenInfo) GetLastUsedAt() string {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return ""
+}
+
+type CreateAccessTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes        []string               `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresInDays int32                  `protobuf:"varint,3,opt,name=expires_in_days,json=expiresInDays,proto3" json:"expires_in_days,omitempty"` // 0 = never expires
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccessTokenRequest) Reset() {
+	*x = CreateAccessTokenRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccessTokenRequest) ProtoMessage() {}
+
+func (x *CreateAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateAccessTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAccessTokenRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateAccessTokenRequest) GetExpiresInDays() int32 {
+	if x != nil {
+		return x.ExpiresInDays
+	}
+	return 0
+}
+
+type CreatedAccessToken struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Info  *AccessTokenInfo       `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	// The token itself; only returned here, never again.
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatedAccessToken) Reset() {
+	*x = CreatedAccessToken{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatedAccessToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatedAccessToken) ProtoMessage() {}
+
+func (x *CreatedAccessToken) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatedAccessToken.ProtoReflect.Descriptor instead.
+func (*CreatedAccessToken) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreatedAccessToken) GetInfo() *AccessTokenInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *CreatedAccessToken) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type AccessTokenList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []*AccessTokenInfo     `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessTokenList) Reset() {
+	*x = AccessTokenList{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessTokenList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessTokenList) ProtoMessage() {}
+
+func (x *AccessTokenList) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessTokenList.ProtoReflect.Descriptor instead.
+func (*AccessTokenList) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AccessTokenList) GetTokens() []*AccessTokenInfo {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type RevokeAccessTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAccessTokenRequest) Reset() {
+	*x = RevokeAccessTokenRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAccessTokenRequest) ProtoMessage() {}
+
+func (x *RevokeAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RevokeAccessTokenRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// A login on one device: one refresh token family.
+type SessionInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,2,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastSeenAt    string                 `protobuf:"bytes,5,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	Current       bool                   `protobuf:"varint,6,opt,name=current,proto3" json:"current,omitempty"` // the session the request was made with
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionInfo) Reset() {
+	*x = SessionInfo{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionInfo) ProtoMessage() {}
+
+func (x *SessionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionInfo.ProtoReflect.Descriptor instead.
+func (*SessionInfo) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SessionInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetLastSeenAt() string {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type SessionList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*SessionInfo         `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionList) Reset() {
+	*x = SessionList{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionList) ProtoMessage() {}
+
+func (x *SessionList) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionList.ProtoReflect.Descriptor instead.
+func (*SessionList) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SessionList) GetSessions() []*SessionInfo {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RevokeSessionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Password      string                 `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"` // re-confirms the caller's identity
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteAccountRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type AccountDeletion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// When the account and its files are erased unless the deletion is cancelled.
+	DeleteAfter   string `protobuf:"bytes,1,opt,name=delete_after,json=deleteAfter,proto3" json:"delete_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountDeletion) Reset() {
+	*x = AccountDeletion{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountDeletion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountDeletion) ProtoMessage() {}
+
+func (x *AccountDeletion) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountDeletion.ProtoReflect.Descriptor instead.
+func (*AccountDeletion) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AccountDeletion) GetDeleteAfter() string {
+	if x != nil {
+		return x.DeleteAfter
+	}
+	return ""
+}
+
+type UserLookup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserLookup) Reset() {
+	*x = UserLookup{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserLookup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserLookup) ProtoMessage() {}
+
+func (x *UserLookup) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserLookup.ProtoReflect.Descriptor instead.
+func (*UserLookup) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UserLookup) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// ===== Organizations (served by the auth service) =====
+type Org struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"` // the caller's role in the org
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Org) Reset() {
+	*x = Org{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Org) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Org) ProtoMessage() {}
+
+func (x *Org) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Org.ProtoReflect.Descriptor instead.
+func (*Org) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Org) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Org) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Org) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Org) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type OrgList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orgs          []*Org                 `protobuf:"bytes,1,rep,name=orgs,proto3" json:"orgs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgList) Reset() {
+	*x = OrgList{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgList) ProtoMessage() {}
+
+func (x *OrgList) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgList.ProtoReflect.Descriptor instead.
+func (*OrgList) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *OrgList) GetOrgs() []*Org {
+	if x != nil {
+		return x.Orgs
+	}
+	return nil
+}
+
+type CreateOrgRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrgRequest) Reset() {
+	*x = CreateOrgRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrgRequest) ProtoMessage() {}
+
+func (x *CreateOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrgRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrgRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateOrgRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type OrgRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         int64                  `protobuf:"varint,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgRequest) Reset() {
+	*x = OrgRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgRequest) ProtoMessage() {}
+
+func (x *OrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgRequest.ProtoReflect.Descriptor instead.
+func (*OrgRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *OrgRequest) GetOrgId() int64 {
+	if x != nil {
+		return x.OrgId
+	}
+	return 0
+}
+
+type OrgMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	JoinedAt      string                 `protobuf:"bytes,4,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgMember) Reset() {
+	*x = OrgMember{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgMember) ProtoMessage() {}
+
+func (x *OrgMember) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgMember.ProtoReflect.Descriptor instead.
+func (*OrgMember) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *OrgMember) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *OrgMember) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *OrgMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *OrgMember) GetJoinedAt() string {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return ""
+}
+
+type OrgMemberList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*OrgMember           `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgMemberList) Reset() {
+	*x = OrgMemberList{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgMemberList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgMemberList) ProtoMessage() {}
+
+func (x *OrgMemberList) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgMemberList.ProtoReflect.Descriptor instead.
+func (*OrgMemberList) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *OrgMemberList) GetMembers() []*OrgMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// Identifies a member; role is only read by SetMemberRole.
+type OrgMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         int64                  `protobuf:"varint,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgMemberRequest) Reset() {
+	*x = OrgMemberRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgMemberRequest) ProtoMessage() {}
+
+func (x *OrgMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgMemberRequest.ProtoReflect.Descriptor instead.
+func (*OrgMemberRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *OrgMemberRequest) GetOrgId() int64 {
+	if x != nil {
+		return x.OrgId
+	}
+	return 0
+}
+
+func (x *OrgMemberRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *OrgMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type InviteMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         int64                  `protobuf:"varint,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"` // "admin" or "member"; defaults to "member"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteMemberRequest) Reset() {
+	*x = InviteMemberRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteMemberRequest) ProtoMessage() {}
+
+func (x *InviteMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteMemberRequest.ProtoReflect.Descriptor instead.
+func (*InviteMemberRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *InviteMemberRequest) GetOrgId() int64 {
+	if x != nil {
+		return x.OrgId
+	}
+	return 0
+}
+
+func (x *InviteMemberRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *InviteMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type OrgInvitation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId         int64                  `protobuf:"varint,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	OrgName       string                 `protobuf:"bytes,3,opt,name=org_name,json=orgName,proto3" json:"org_name,omitempty"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	InvitedBy     string                 `protobuf:"bytes,6,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"` // inviter's email
+	ExpiresAt     string                 `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgInvitation) Reset() {
+	*x = OrgInvitation{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgInvitation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgInvitation) ProtoMessage() {}
+
+func (x *OrgInvitation) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgInvitation.ProtoReflect.Descriptor instead.
+func (*OrgInvitation) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *OrgInvitation) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OrgInvitation) GetOrgId() int64 {
+	if x != nil {
+		return x.OrgId
+	}
+	return 0
+}
+
+func (x *OrgInvitation) GetOrgName() string {
+	if x != nil {
+		return x.OrgName
+	}
+	return ""
+}
+
+func (x *OrgInvitation) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *OrgInvitation) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *OrgInvitation) GetInvitedBy() string {
+	if x != nil {
+		return x.InvitedBy
+	}
+	return ""
+}
+
+func (x *OrgInvitation) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type OrgInvitationList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invitations   []*OrgInvitation       `protobuf:"bytes,1,rep,name=invitations,proto3" json:"invitations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgInvitationList) Reset() {
+	*x = OrgInvitationList{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgInvitationList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgInvitationList) ProtoMessage() {}
+
+func (x *OrgInvitationList) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgInvitationList.ProtoReflect.Descriptor instead.
+func (*OrgInvitationList) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *OrgInvitationList) GetInvitations() []*OrgInvitation {
+	if x != nil {
+		return x.Invitations
+	}
+	return nil
+}
+
+type InvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvitationRequest) Reset() {
+	*x = InvitationRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvitationRequest) ProtoMessage() {}
+
+func (x *InvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvitationRequest.ProtoReflect.Descriptor instead.
+func (*InvitationRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *InvitationRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// ===== Admin (served by the auth service; admin role only, reads also auditor) =====
+type AdminUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	EmailVerified bool                   `protobuf:"varint,4,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"`
+	MfaEnabled    bool                   `protobuf:"varint,5,opt,name=mfa_enabled,json=mfaEnabled,proto3" json:"mfa_enabled,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	SuspendedAt   string                 `protobuf:"bytes,7,opt,name=suspended_at,json=suspendedAt,proto3" json:"suspended_at,omitempty"` // empty = not suspended
+	SuspendReason string                 `protobuf:"bytes,8,opt,name=suspend_reason,json=suspendReason,proto3" json:"suspend_reason,omitempty"`
+	DeleteAfter   string                 `protobuf:"bytes,9,opt,name=delete_after,json=deleteAfter,proto3" json:"delete_after,omitempty"` // empty = no deletion pending
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminUser) Reset() {
+	*x = AdminUser{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminUser) ProtoMessage() {}
+
+func (x *AdminUser) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminUser.ProtoReflect.Descriptor instead.
+func (*AdminUser) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AdminUser) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AdminUser) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *AdminUser) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *AdminUser) GetEmailVerified() bool {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return false
+}
+
+func (x *AdminUser) GetMfaEnabled() bool {
+	if x != nil {
+		return x.MfaEnabled
+	}
+	return false
+}
+
+func (x *AdminUser) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *AdminUser) GetSuspendedAt() string {
+	if x != nil {
+		return x.SuspendedAt
+	}
+	return ""
+}
+
+func (x *AdminUser) GetSuspendReason() string {
+	if x != nil {
+		return x.SuspendReason
+	}
+	return ""
+}
+
+func (x *AdminUser) GetDeleteAfter() string {
+	if x != nil {
+		return x.DeleteAfter
+	}
+	return ""
+}
+
+type SearchUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"` // substring of the email; empty lists everyone
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchUsersRequest) Reset() {
+	*x = SearchUsersRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersRequest) ProtoMessage() {}
+
+func (x *SearchUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersRequest.ProtoReflect.Descriptor instead.
+func (*SearchUsersRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SearchUsersRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchUsersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type SearchUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*AdminUser           `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPage      int32                  `protobuf:"varint,2,opt,name=next_page,json=nextPage,proto3" json:"next_page,omitempty"` // 0 = no more
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchUsersResponse) Reset() {
+	*x = SearchUsersResponse{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersResponse) ProtoMessage() {}
+
+func (x *SearchUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersResponse.ProtoReflect.Descriptor instead.
+func (*SearchUsersResponse) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SearchUsersResponse) GetUsers() []*AdminUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *SearchUsersResponse) GetNextPage() int32 {
+	if x != nil {
+		return x.NextPage
+	}
+	return 0
+}
+
+type UserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserRequest) Reset() {
+	*x = UserRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserRequest) ProtoMessage() {}
+
+func (x *UserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserRequest.ProtoReflect.Descriptor instead.
+func (*UserRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *UserRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type UserUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileCount     int64                  `protobuf:"varint,2,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
+	TotalBytes    int64                  `protobuf:"varint,3,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserUsage) Reset() {
+	*x = UserUsage{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserUsage) ProtoMessage() {}
+
+func (x *UserUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserUsage.ProtoReflect.Descriptor instead.
+func (*UserUsage) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UserUsage) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UserUsage) GetFileCount() int64 {
+	if x != nil {
+		return x.FileCount
+	}
+	return 0
+}
+
+func (x *UserUsage) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+type SuspendUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendUserRequest) Reset() {
+	*x = SuspendUserRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendUserRequest) ProtoMessage() {}
+
+func (x *SuspendUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendUserRequest.ProtoReflect.Descriptor instead.
+func (*SuspendUserRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SuspendUserRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SuspendUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CreateSignupInviteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"` // optional; binds the code to this address
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSignupInviteRequest) Reset() {
+	*x = CreateSignupInviteRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSignupInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSignupInviteRequest) ProtoMessage() {}
+
+func (x *CreateSignupInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSignupInviteRequest.ProtoReflect.Descriptor instead.
+func (*CreateSignupInviteRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreateSignupInviteRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type SignupInvite struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // only returned on creation
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignupInvite) Reset() {
+	*x = SignupInvite{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignupInvite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignupInvite) ProtoMessage() {}
+
+func (x *SignupInvite) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignupInvite.ProtoReflect.Descriptor instead.
+func (*SignupInvite) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SignupInvite) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SignupInvite) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *SignupInvite) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *SignupInvite) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type AuditQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       int64                  `protobuf:"varint,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // 0 = any
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`                   // exact, or a prefix ending in "*" ("auth.*")
+	Since         string                 `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`                     // RFC 3339, inclusive
+	Until         string                 `protobuf:"bytes,4,opt,name=until,proto3" json:"until,omitempty"`                     // RFC 3339, exclusive
+	AfterId       int64                  `protobuf:"varint,5,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"` // paging cursor: next_after_id of the previous page
+	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`                    // default and maximum 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditQuery) Reset() {
+	*x = AuditQuery{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditQuery) ProtoMessage() {}
+
+func (x *AuditQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditQuery.ProtoReflect.Descriptor instead.
+func (*AuditQuery) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *AuditQuery) GetActorId() int64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *AuditQuery) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditQuery) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *AuditQuery) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *AuditQuery) GetAfterId() int64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+func (x *AuditQuery) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AuditEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	At            string                 `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"`
+	Service       string                 `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	ActorId       int64                  `protobuf:"varint,4,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	Action        string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	Target        string                 `protobuf:"bytes,6,opt,name=target,proto3" json:"target,omitempty"`
+	Ip            string                 `protobuf:"bytes,7,opt,name=ip,proto3" json:"ip,omitempty"`
+	Details       map[string]string      `protobuf:"bytes,8,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	PrevHash      string                 `protobuf:"bytes,9,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Hash          string                 `protobuf:"bytes,10,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *AuditEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetAt() string {
+	if x != nil {
+		return x.At
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetActorId() int64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetPrevHash() string {
+	if x != nil {
+		return x.PrevHash
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type AuditEntryList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextAfterId   int64                  `protobuf:"varint,2,opt,name=next_after_id,json=nextAfterId,proto3" json:"next_after_id,omitempty"` // 0 = no more
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntryList) Reset() {
+	*x = AuditEntryList{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntryList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntryList) ProtoMessage() {}
+
+func (x *AuditEntryList) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntryList.ProtoReflect.Descriptor instead.
+func (*AuditEntryList) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *AuditEntryList) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *AuditEntryList) GetNextAfterId() int64 {
+	if x != nil {
+		return x.NextAfterId
+	}
+	return 0
+}
+
+type AuditVerification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Checked       int64                  `protobuf:"varint,2,opt,name=checked,proto3" json:"checked,omitempty"`                           // entries verified before stopping
+	BrokenAtId    int64                  `protobuf:"varint,3,opt,name=broken_at_id,json=brokenAtId,proto3" json:"broken_at_id,omitempty"` // first bad entry when !ok
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditVerification) Reset() {
+	*x = AuditVerification{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditVerification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditVerification) ProtoMessage() {}
+
+func (x *AuditVerification) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditVerification.ProtoReflect.Descriptor instead.
+func (*AuditVerification) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *AuditVerification) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *AuditVerification) GetChecked() int64 {
+	if x != nil {
+		return x.Checked
+	}
+	return 0
+}
+
+func (x *AuditVerification) GetBrokenAtId() int64 {
+	if x != nil {
+		return x.BrokenAtId
+	}
+	return 0
+}
+
+func (x *AuditVerification) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ===== Files (metadata only, not bytes) =====
+// In requests, owner_id is the signed-in user making the call. On a file
+// shared with them it is not the file's owner; FileItem.owner_id always is.
+type FileItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerId       int64                  `protobuf:"varint,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Mime          string                 `protobuf:"bytes,4,opt,name=mime,proto3" json:"mime,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	VersionId     string                 `protobuf:"bytes,7,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"` // current version: "1", "2", ...; "" for folders
+	ParentId      int64                  `protobuf:"varint,8,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`   // containing folder; 0 = top level
+	IsFolder      bool                   `protobuf:"varint,9,opt,name=is_folder,json=isFolder,proto3" json:"is_folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileItem) Reset() {
+	*x = FileItem{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileItem) ProtoMessage() {}
+
+func (x *FileItem) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileItem.ProtoReflect.Descriptor instead.
+func (*FileItem) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *FileItem) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *FileItem) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *FileItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileItem) GetMime() string {
+	if x != nil {
+		return x.Mime
+	}
+	return ""
+}
+
+func (x *FileItem) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *FileItem) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *FileItem) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *FileItem) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *FileItem) GetIsFolder() bool {
+	if x != nil {
+		return x.IsFolder
+	}
+	return false
+}
+
+type ListFilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilesRequest) Reset() {
+	*x = ListFilesRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesRequest) ProtoMessage() {}
+
+func (x *ListFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesRequest.ProtoReflect.Descriptor instead.
+func (*ListFilesRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListFilesRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListFilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*FileItem            `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	NextPage      int32                  `protobuf:"varint,2,opt,name=next_page,json=nextPage,proto3" json:"next_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilesResponse) Reset() {
+	*x = ListFilesResponse{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesResponse) ProtoMessage() {}
+
+func (x *ListFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesResponse.ProtoReflect.Descriptor instead.
+func (*ListFilesResponse) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListFilesResponse) GetFiles() []*FileItem {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ListFilesResponse) GetNextPage() int32 {
+	if x != nil {
+		return x.NextPage
+	}
+	return 0
+}
+
+type ConfirmUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	ObjectKey     string                 `protobuf:"bytes,2,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	Mime          string                 `protobuf:"bytes,4,opt,name=mime,proto3" json:"mime,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmUploadRequest) Reset() {
+	*x = ConfirmUploadRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmUploadRequest) ProtoMessage() {}
+
+func (x *ConfirmUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmUploadRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmUploadRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ConfirmUploadRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *ConfirmUploadRequest) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *ConfirmUploadRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ConfirmUploadRequest) GetMime() string {
+	if x != nil {
+		return x.Mime
+	}
+	return ""
+}
+
+func (x *ConfirmUploadRequest) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+type ConfirmUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileItem              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmUploadResponse) Reset() {
+	*x = ConfirmUploadResponse{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmUploadResponse) ProtoMessage() {}
+
+func (x *ConfirmUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmUploadResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmUploadResponse) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ConfirmUploadResponse) GetFile() *FileItem {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+type DownloadURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadURLRequest) Reset() {
+	*x = DownloadURLRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadURLRequest) ProtoMessage() {}
+
+func (x *DownloadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadURLRequest.ProtoReflect.Descriptor instead.
+func (*DownloadURLRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DownloadURLRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *DownloadURLRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+type DownloadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DownloadUrl   string                 `protobuf:"bytes,1,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadURLResponse) Reset() {
+	*x = DownloadURLResponse{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadURLResponse) ProtoMessage() {}
+
+func (x *DownloadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadURLResponse.ProtoReflect.Descriptor instead.
+func (*DownloadURLResponse) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DownloadURLResponse) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+func (x *DownloadURLResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type DeleteFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileRequest) Reset() {
+	*x = DeleteFileRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileRequest) ProtoMessage() {}
+
+func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *DeleteFileRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *DeleteFileRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+type DeleteFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileResponse) Reset() {
+	*x = DeleteFileResponse{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileResponse) ProtoMessage() {}
+
+func (x *DeleteFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFileResponse) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *DeleteFileResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+// PrepareUploadRequest describes a file about to be uploaded; folder_id 0
+// is the top level.
+type PrepareUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	FolderId      int64                  `protobuf:"varint,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	Mime          string                 `protobuf:"bytes,4,opt,name=mime,proto3" json:"mime,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	FileId        int64                  `protobuf:"varint,6,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"` // upload a new version of this file; folder_id and filename are ignored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrepareUploadRequest) Reset() {
+	*x = PrepareUploadRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrepareUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareUploadRequest) ProtoMessage() {}
+
+func (x *PrepareUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareUploadRequest.ProtoReflect.Descriptor instead.
+func (*PrepareUploadRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *PrepareUploadRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *PrepareUploadRequest) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *PrepareUploadRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *PrepareUploadRequest) GetMime() string {
+	if x != nil {
+		return x.Mime
+	}
+	return ""
+}
+
+func (x *PrepareUploadRequest) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *PrepareUploadRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+type PrepareUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ObjectKey     string                 `protobuf:"bytes,1,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"` // presign an upload for this key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrepareUploadResponse) Reset() {
+	*x = PrepareUploadResponse{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrepareUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareUploadResponse) ProtoMessage() {}
+
+func (x *PrepareUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareUploadResponse.ProtoReflect.Descriptor instead.
+func (*PrepareUploadResponse) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *PrepareUploadResponse) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+// UpdateFileRequest renames and/or moves a file or folder. Unset fields are
+// left alone.
+type UpdateFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Name          *string                `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	ParentId      *int64                 `protobuf:"varint,4,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"` // 0 = top level
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFileRequest) Reset() {
+	*x = UpdateFileRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFileRequest) ProtoMessage() {}
+
+func (x *UpdateFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFileRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *UpdateFileRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *UpdateFileRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *UpdateFileRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateFileRequest) GetParentId() int64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+type CreateFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	ParentId      int64                  `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // 0 = top level
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFolderRequest) Reset() {
+	*x = CreateFolderRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFolderRequest) ProtoMessage() {}
+
+func (x *CreateFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFolderRequest.ProtoReflect.Descriptor instead.
+func (*CreateFolderRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *CreateFolderRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *CreateFolderRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *CreateFolderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListChildrenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	FolderId      int64                  `protobuf:"varint,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"` // 0 = top level
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChildrenRequest) Reset() {
+	*x = ListChildrenRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChildrenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChildrenRequest) ProtoMessage() {}
+
+func (x *ListChildrenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChildrenRequest.ProtoReflect.Descriptor instead.
+func (*ListChildrenRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListChildrenRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *ListChildrenRequest) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *ListChildrenRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListChildrenRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type FilePathRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilePathRequest) Reset() {
+	*x = FilePathRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilePathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilePathRequest) ProtoMessage() {}
+
+func (x *FilePathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilePathRequest.ProtoReflect.Descriptor instead.
+func (*FilePathRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *FilePathRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *FilePathRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+// FilePath is a file or folder and its ancestors, top level first.
+type FilePath struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*FileItem            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilePath) Reset() {
+	*x = FilePath{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilePath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilePath) ProtoMessage() {}
+
+func (x *FilePath) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilePath.ProtoReflect.Descriptor instead.
+func (*FilePath) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *FilePath) GetItems() []*FileItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ResolvePathRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"` // "Reports/2024/q1.pdf"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvePathRequest) Reset() {
+	*x = ResolvePathRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvePathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvePathRequest) ProtoMessage() {}
+
+func (x *ResolvePathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvePathRequest.ProtoReflect.Descriptor instead.
+func (*ResolvePathRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ResolvePathRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *ResolvePathRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type ListTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ListTrashRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *ListTrashRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListTrashRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// TrashedItem is a deleted file or folder and when the janitor purges it.
+// A folder's contents go with it and aren't listed separately.
+type TrashedItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileItem              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	DeletedAt     string                 `protobuf:"bytes,2,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	PurgeAt       string                 `protobuf:"bytes,3,opt,name=purge_at,json=purgeAt,proto3" json:"purge_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrashedItem) Reset() {
+	*x = TrashedItem{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrashedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashedItem) ProtoMessage() {}
+
+func (x *TrashedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashedItem.ProtoReflect.Descriptor instead.
+func (*TrashedItem) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *TrashedItem) GetFile() *FileItem {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *TrashedItem) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+func (x *TrashedItem) GetPurgeAt() string {
+	if x != nil {
+		return x.PurgeAt
+	}
+	return ""
+}
+
+type ListTrashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*TrashedItem         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextPage      int32                  `protobuf:"varint,2,opt,name=next_page,json=nextPage,proto3" json:"next_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashResponse) ProtoMessage() {}
+
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashResponse.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListTrashResponse) GetItems() []*TrashedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListTrashResponse) GetNextPage() int32 {
+	if x != nil {
+		return x.NextPage
+	}
+	return 0
+}
+
+type FileVersionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileVersionsRequest) Reset() {
+	*x = FileVersionsRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileVersionsRequest) ProtoMessage() {}
+
+func (x *FileVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileVersionsRequest.ProtoReflect.Descriptor instead.
+func (*FileVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *FileVersionsRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *FileVersionsRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+type FileVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VersionId     string                 `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	Mime          string                 `protobuf:"bytes,2,opt,name=mime,proto3" json:"mime,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Current       bool                   `protobuf:"varint,5,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileVersion) Reset() {
+	*x = FileVersion{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileVersion) ProtoMessage() {}
+
+func (x *FileVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileVersion.ProtoReflect.Descriptor instead.
+func (*FileVersion) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *FileVersion) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *FileVersion) GetMime() string {
+	if x != nil {
+		return x.Mime
+	}
+	return ""
+}
+
+func (x *FileVersion) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *FileVersion) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *FileVersion) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+// FileVersions are a file's versions still kept, newest first.
+type FileVersions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Versions      []*FileVersion         `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileVersions) Reset() {
+	*x = FileVersions{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileVersions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileVersions) ProtoMessage() {}
+
+func (x *FileVersions) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileVersions.ProtoReflect.Descriptor instead.
+func (*FileVersions) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *FileVersions) GetVersions() []*FileVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type VersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	VersionId     string                 `protobuf:"bytes,3,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VersionRequest) Reset() {
+	*x = VersionRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionRequest) ProtoMessage() {}
+
+func (x *VersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionRequest.ProtoReflect.Descriptor instead.
+func (*VersionRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *VersionRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *VersionRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *VersionRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+// ShareRequest grants a user, by ID or email, a role on a file or folder.
+// Sharing again with the same person changes their role.
+type ShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // either this
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`                  // or this; applies once the address is verified
+	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`                    // "viewer", "commenter" or "editor"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareRequest) Reset() {
+	*x = ShareRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareRequest) ProtoMessage() {}
+
+func (x *ShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareRequest.ProtoReflect.Descriptor instead.
+func (*ShareRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ShareRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *ShareRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *ShareRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ShareRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ShareRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type Share struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"` // may be a folder the file is in
+	UserId        int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Share) Reset() {
+	*x = Share{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Share) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Share) ProtoMessage() {}
+
+func (x *Share) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Share.ProtoReflect.Descriptor instead.
+func (*Share) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *Share) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Share) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *Share) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Share) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Share) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Share) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type SharesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharesRequest) Reset() {
+	*x = SharesRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharesRequest) ProtoMessage() {}
+
+func (x *SharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharesRequest.ProtoReflect.Descriptor instead.
+func (*SharesRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *SharesRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *SharesRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+type Shares struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []*Share               `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Shares) Reset() {
+	*x = Shares{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Shares) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shares) ProtoMessage() {}
+
+func (x *Shares) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shares.ProtoReflect.Descriptor instead.
+func (*Shares) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *Shares) GetShares() []*Share {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
 }
 
-type ListFilesResponse struct {
+type UnshareRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Files         []*FileItem            `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
-	NextPage      int32                  `protobuf:"varint,2,opt,name=next_page,json=nextPage,proto3" json:"next_page,omitempty"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	ShareId       int64                  `protobuf:"varint,3,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFilesResponse) Reset() {
-	*x = ListFilesResponse{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[6]
+func (x *UnshareRequest) Reset() {
+	*x = UnshareRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFilesResponse) String() string {
+func (x *UnshareRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFilesResponse) ProtoMessage() {}
+func (*UnshareRequest) ProtoMessage() {}
 
-func (x *ListFilesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[6]
+func (x *UnshareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -408,51 +4813,56 @@ func (x *ListFilesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFilesResponse.ProtoReflect.Descriptor instead.
-func (*ListFilesResponse) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use UnshareRequest.ProtoReflect.Descriptor instead.
+func (*UnshareRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *ListFilesResponse) GetFiles() []*FileItem {
+func (x *UnshareRequest) GetOwnerId() int64 {
 	if x != nil {
-		return x.Files
+		return x.OwnerId
 	}
-	return nil
+	return 0
 }
 
-func (x *ListFilesResponse) GetNextPage() int32 {
+func (x *UnshareRequest) GetFileId() int64 {
 	if x != nil {
-		return x.NextPage
+		return x.FileId
 	}
 	return 0
 }
 
-type ConfirmUploadRequest struct {
+func (x *UnshareRequest) GetShareId() int64 {
+	if x != nil {
+		return x.ShareId
+	}
+	return 0
+}
+
+type SharedWithMeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
-	ObjectKey     string                 `protobuf:"bytes,2,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
-	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
-	Mime          string                 `protobuf:"bytes,4,opt,name=mime,proto3" json:"mime,omitempty"`
-	SizeBytes     int64                  `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConfirmUploadRequest) Reset() {
-	*x = ConfirmUploadRequest{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[7]
+func (x *SharedWithMeRequest) Reset() {
+	*x = SharedWithMeRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfirmUploadRequest) String() string {
+func (x *SharedWithMeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfirmUploadRequest) ProtoMessage() {}
+func (*SharedWithMeRequest) ProtoMessage() {}
 
-func (x *ConfirmUploadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[7]
+func (x *SharedWithMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -463,68 +4873,55 @@ func (x *ConfirmUploadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfirmUploadRequest.ProtoReflect.Descriptor instead.
-func (*ConfirmUploadRequest) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use SharedWithMeRequest.ProtoReflect.Descriptor instead.
+func (*SharedWithMeRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *ConfirmUploadRequest) GetOwnerId() int64 {
+func (x *SharedWithMeRequest) GetOwnerId() int64 {
 	if x != nil {
 		return x.OwnerId
 	}
 	return 0
 }
 
-func (x *ConfirmUploadRequest) GetObjectKey() string {
-	if x != nil {
-		return x.ObjectKey
-	}
-	return ""
-}
-
-func (x *ConfirmUploadRequest) GetFilename() string {
-	if x != nil {
-		return x.Filename
-	}
-	return ""
-}
-
-func (x *ConfirmUploadRequest) GetMime() string {
+func (x *SharedWithMeRequest) GetPage() int32 {
 	if x != nil {
-		return x.Mime
+		return x.Page
 	}
-	return ""
+	return 0
 }
 
-func (x *ConfirmUploadRequest) GetSizeBytes() int64 {
+func (x *SharedWithMeRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.SizeBytes
+		return x.PageSize
 	}
 	return 0
 }
 
-type ConfirmUploadResponse struct {
+type SharedItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	File          *FileItem              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConfirmUploadResponse) Reset() {
-	*x = ConfirmUploadResponse{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[8]
+func (x *SharedItem) Reset() {
+	*x = SharedItem{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfirmUploadResponse) String() string {
+func (x *SharedItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfirmUploadResponse) ProtoMessage() {}
+func (*SharedItem) ProtoMessage() {}
 
-func (x *ConfirmUploadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[8]
+func (x *SharedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -535,41 +4932,48 @@ func (x *ConfirmUploadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfirmUploadResponse.ProtoReflect.Descriptor instead.
-func (*ConfirmUploadResponse) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use SharedItem.ProtoReflect.Descriptor instead.
+func (*SharedItem) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *ConfirmUploadResponse) GetFile() *FileItem {
+func (x *SharedItem) GetFile() *FileItem {
 	if x != nil {
 		return x.File
 	}
 	return nil
 }
 
-type DownloadURLRequest struct {
+func (x *SharedItem) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type SharedWithMeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
-	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Items         []*SharedItem          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextPage      int32                  `protobuf:"varint,2,opt,name=next_page,json=nextPage,proto3" json:"next_page,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadURLRequest) Reset() {
-	*x = DownloadURLRequest{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[9]
+func (x *SharedWithMeResponse) Reset() {
+	*x = SharedWithMeResponse{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadURLRequest) String() string {
+func (x *SharedWithMeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadURLRequest) ProtoMessage() {}
+func (*SharedWithMeResponse) ProtoMessage() {}
 
-func (x *DownloadURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[9]
+func (x *SharedWithMeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -580,48 +4984,48 @@ func (x *DownloadURLRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadURLRequest.ProtoReflect.Descriptor instead.
-func (*DownloadURLRequest) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use SharedWithMeResponse.ProtoReflect.Descriptor instead.
+func (*SharedWithMeResponse) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *DownloadURLRequest) GetOwnerId() int64 {
+func (x *SharedWithMeResponse) GetItems() []*SharedItem {
 	if x != nil {
-		return x.OwnerId
+		return x.Items
 	}
-	return 0
+	return nil
 }
 
-func (x *DownloadURLRequest) GetFileId() int64 {
+func (x *SharedWithMeResponse) GetNextPage() int32 {
 	if x != nil {
-		return x.FileId
+		return x.NextPage
 	}
 	return 0
 }
 
-type DownloadURLResponse struct {
+type TrashRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DownloadUrl   string                 `protobuf:"bytes,1,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
-	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"` // PurgeNow: 0 = everything in the trash
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownloadURLResponse) Reset() {
-	*x = DownloadURLResponse{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[10]
+func (x *TrashRequest) Reset() {
+	*x = TrashRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadURLResponse) String() string {
+func (x *TrashRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadURLResponse) ProtoMessage() {}
+func (*TrashRequest) ProtoMessage() {}
 
-func (x *DownloadURLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[10]
+func (x *TrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -632,48 +5036,47 @@ func (x *DownloadURLResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownloadURLResponse.ProtoReflect.Descriptor instead.
-func (*DownloadURLResponse) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use TrashRequest.ProtoReflect.Descriptor instead.
+func (*TrashRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *DownloadURLResponse) GetDownloadUrl() string {
+func (x *TrashRequest) GetOwnerId() int64 {
 	if x != nil {
-		return x.DownloadUrl
+		return x.OwnerId
 	}
-	return ""
+	return 0
 }
 
-func (x *DownloadURLResponse) GetExpiresAt() string {
+func (x *TrashRequest) GetFileId() int64 {
 	if x != nil {
-		return x.ExpiresAt
+		return x.FileId
 	}
-	return ""
+	return 0
 }
 
-type DeleteFileRequest struct {
+type UsageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OwnerId       int64                  `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
-	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteFileRequest) Reset() {
-	*x = DeleteFileRequest{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[11]
+func (x *UsageRequest) Reset() {
+	*x = UsageRequest{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteFileRequest) String() string {
+func (x *UsageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteFileRequest) ProtoMessage() {}
+func (*UsageRequest) ProtoMessage() {}
 
-func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[11]
+func (x *UsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -684,47 +5087,42 @@ func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteFileRequest.ProtoReflect.Descriptor instead.
-func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use UsageRequest.ProtoReflect.Descriptor instead.
+func (*UsageRequest) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *DeleteFileRequest) GetOwnerId() int64 {
+func (x *UsageRequest) GetOwnerId() int64 {
 	if x != nil {
 		return x.OwnerId
 	}
 	return 0
 }
 
-func (x *DeleteFileRequest) GetFileId() int64 {
-	if x != nil {
-		return x.FileId
-	}
-	return 0
-}
-
-type DeleteFileResponse struct {
+// Files that are not deleted, and their total size.
+type UsageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	FileCount     int64                  `protobuf:"varint,1,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
+	TotalBytes    int64                  `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteFileResponse) Reset() {
-	*x = DeleteFileResponse{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[12]
+func (x *UsageResponse) Reset() {
+	*x = UsageResponse{}
+	mi := &file_godrive_v1_godrive_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteFileResponse) String() string {
+func (x *UsageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteFileResponse) ProtoMessage() {}
+func (*UsageResponse) ProtoMessage() {}
 
-func (x *DeleteFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[12]
+func (x *UsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_godrive_v1_godrive_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -735,16 +5133,23 @@ func (x *DeleteFileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteFileResponse.ProtoReflect.Descriptor instead.
-func (*DeleteFileResponse) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use UsageResponse.ProtoReflect.Descriptor instead.
+func (*UsageResponse) Descriptor() ([]byte, []int) {
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{88}
 }
 
-func (x *DeleteFileResponse) GetOk() bool {
+func (x *UsageResponse) GetFileCount() int64 {
 	if x != nil {
-		return x.Ok
+		return x.FileCount
 	}
-	return false
+	return 0
+}
+
+func (x *UsageResponse) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
 }
 
 type PresignUploadRequest struct {
@@ -752,13 +5157,14 @@ type PresignUploadRequest struct {
 	ObjectKey     string                 `protobuf:"bytes,1,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
 	Mime          string                 `protobuf:"bytes,2,opt,name=mime,proto3" json:"mime,omitempty"`
 	SizeBytes     int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	MaxBytes      int64                  `protobuf:"varint,4,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"` // 0 for no cap; otherwise a POST policy that MinIO enforces
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PresignUploadRequest) Reset() {
 	*x = PresignUploadRequest{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[13]
+	mi := &file_godrive_v1_godrive_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -770,7 +5176,7 @@ func (x *PresignUploadRequest) String() string {
 func (*PresignUploadRequest) ProtoMessage() {}
 
 func (x *PresignUploadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[13]
+	mi := &file_godrive_v1_godrive_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -783,7 +5189,7 @@ func (x *PresignUploadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PresignUploadRequest.ProtoReflect.Descriptor instead.
 func (*PresignUploadRequest) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{13}
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{89}
 }
 
 func (x *PresignUploadRequest) GetObjectKey() string {
@@ -807,18 +5213,27 @@ func (x *PresignUploadRequest) GetSizeBytes() int64 {
 	return 0
 }
 
+func (x *PresignUploadRequest) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
 type PresignUploadResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
 	Headers       map[string]string      `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	ExpiresAt     string                 `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Method        string                 `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`                                                                           // "PUT", or "POST" for a capped upload
+	Fields        map[string]string      `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // POST only: form fields to send before the file
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PresignUploadResponse) Reset() {
 	*x = PresignUploadResponse{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[14]
+	mi := &file_godrive_v1_godrive_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -830,7 +5245,7 @@ func (x *PresignUploadResponse) String() string {
 func (*PresignUploadResponse) ProtoMessage() {}
 
 func (x *PresignUploadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[14]
+	mi := &file_godrive_v1_godrive_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -843,7 +5258,7 @@ func (x *PresignUploadResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PresignUploadResponse.ProtoReflect.Descriptor instead.
 func (*PresignUploadResponse) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{14}
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{90}
 }
 
 func (x *PresignUploadResponse) GetUrl() string {
@@ -867,6 +5282,20 @@ func (x *PresignUploadResponse) GetExpiresAt() string {
 	return ""
 }
 
+func (x *PresignUploadResponse) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *PresignUploadResponse) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
 type PresignDownloadRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ObjectKey     string                 `protobuf:"bytes,1,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
@@ -876,7 +5305,7 @@ type PresignDownloadRequest struct {
 
 func (x *PresignDownloadRequest) Reset() {
 	*x = PresignDownloadRequest{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[15]
+	mi := &file_godrive_v1_godrive_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -888,7 +5317,7 @@ func (x *PresignDownloadRequest) String() string {
 func (*PresignDownloadRequest) ProtoMessage() {}
 
 func (x *PresignDownloadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[15]
+	mi := &file_godrive_v1_godrive_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -901,7 +5330,7 @@ func (x *PresignDownloadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PresignDownloadRequest.ProtoReflect.Descriptor instead.
 func (*PresignDownloadRequest) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{15}
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{91}
 }
 
 func (x *PresignDownloadRequest) GetObjectKey() string {
@@ -921,7 +5350,7 @@ type PresignDownloadResponse struct {
 
 func (x *PresignDownloadResponse) Reset() {
 	*x = PresignDownloadResponse{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[16]
+	mi := &file_godrive_v1_godrive_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -933,7 +5362,7 @@ func (x *PresignDownloadResponse) String() string {
 func (*PresignDownloadResponse) ProtoMessage() {}
 
 func (x *PresignDownloadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[16]
+	mi := &file_godrive_v1_godrive_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -946,7 +5375,7 @@ func (x *PresignDownloadResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PresignDownloadResponse.ProtoReflect.Descriptor instead.
 func (*PresignDownloadResponse) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{16}
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{92}
 }
 
 func (x *PresignDownloadResponse) GetUrl() string {
@@ -972,7 +5401,7 @@ type DeleteObjectRequest struct {
 
 func (x *DeleteObjectRequest) Reset() {
 	*x = DeleteObjectRequest{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[17]
+	mi := &file_godrive_v1_godrive_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -984,7 +5413,7 @@ func (x *DeleteObjectRequest) String() string {
 func (*DeleteObjectRequest) ProtoMessage() {}
 
 func (x *DeleteObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[17]
+	mi := &file_godrive_v1_godrive_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -997,7 +5426,7 @@ func (x *DeleteObjectRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteObjectRequest.ProtoReflect.Descriptor instead.
 func (*DeleteObjectRequest) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{17}
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{93}
 }
 
 func (x *DeleteObjectRequest) GetObjectKey() string {
@@ -1016,7 +5445,7 @@ type DeleteObjectResponse struct {
 
 func (x *DeleteObjectResponse) Reset() {
 	*x = DeleteObjectResponse{}
-	mi := &file_godrive_v1_godrive_proto_msgTypes[18]
+	mi := &file_godrive_v1_godrive_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1028,7 +5457,7 @@ func (x *DeleteObjectResponse) String() string {
 func (*DeleteObjectResponse) ProtoMessage() {}
 
 func (x *DeleteObjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_godrive_v1_godrive_proto_msgTypes[18]
+	mi := &file_godrive_v1_godrive_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1041,7 +5470,7 @@ func (x *DeleteObjectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteObjectResponse.ProtoReflect.Descriptor instead.
 func (*DeleteObjectResponse) Descriptor() ([]byte, []int) {
-	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{18}
+	return file_godrive_v1_godrive_proto_rawDescGZIP(), []int{94}
 }
 
 func (x *DeleteObjectResponse) GetOk() bool {
@@ -1057,19 +5486,226 @@ const file_godrive_v1_godrive_proto_rawDesc = "" +
 	"\n" +
 	"\x18godrive/v1/godrive.proto\x12\n" +
 	"godrive.v1\"\a\n" +
-	"\x05Empty\"K\n" +
+	"\x05Empty\"\xd0\x01\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\x03 \x01(\tR\tcreatedAt\"?\n" +
+	"created_at\x18\x03 \x01(\tR\tcreatedAt\x12%\n" +
+	"\x0eemail_verified\x18\x04 \x01(\bR\remailVerified\x12\x16\n" +
+	"\x06scopes\x18\x05 \x03(\tR\x06scopes\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\x12\x15\n" +
+	"\x06org_id\x18\a \x01(\x03R\x05orgId\x12\x19\n" +
+	"\borg_role\x18\b \x01(\tR\aorgRole\"?\n" +
 	"\vCredentials\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"I\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"b\n" +
+	"\rSignUpRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1f\n" +
+	"\vinvite_code\x18\x03 \x01(\tR\n" +
+	"inviteCode\"\xdc\x01\n" +
 	"\x05Token\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1d\n" +
 	"\n" +
-	"expires_at\x18\x02 \x01(\tR\texpiresAt\"\xba\x01\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\x12,\n" +
+	"\x12refresh_expires_at\x18\x04 \x01(\tR\x10refreshExpiresAt\x12!\n" +
+	"\fmfa_required\x18\x05 \x01(\bR\vmfaRequired\x12\x1b\n" +
+	"\tmfa_token\x18\x06 \x01(\tR\bmfaToken\"5\n" +
+	"\x0eRefreshRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\",\n" +
+	"\x14PasswordResetRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"O\n" +
+	"\x14ResetPasswordRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"*\n" +
+	"\x12VerifyEmailRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"1\n" +
+	"\x19ResendVerificationRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"(\n" +
+	"\x10MagicLinkRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"-\n" +
+	"\x15MagicLinkLoginRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"I\n" +
+	"\x0eTOTPEnrollment\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x1f\n" +
+	"\votpauth_url\x18\x02 \x01(\tR\n" +
+	"otpauthUrl\"\x1e\n" +
+	"\bTOTPCode\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"%\n" +
+	"\rRecoveryCodes\x12\x14\n" +
+	"\x05codes\x18\x01 \x03(\tR\x05codes\"B\n" +
+	"\x0fMFALoginRequest\x12\x1b\n" +
+	"\tmfa_token\x18\x01 \x01(\tR\bmfaToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"*\n" +
+	"\x0fResetMFARequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"A\n" +
+	"\x12SetUserRoleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"5\n" +
+	"\vOIDCAuthURL\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"8\n" +
+	"\fOIDCCallback\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"\xc5\x01\n" +
+	"\x0fAccessTokenInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x12\x16\n" +
+	"\x06prefix\x18\x04 \x01(\tR\x06prefix\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\tR\texpiresAt\x12 \n" +
+	"\flast_used_at\x18\a \x01(\tR\n" +
+	"lastUsedAt\"n\n" +
+	"\x18CreateAccessTokenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\x12&\n" +
+	"\x0fexpires_in_days\x18\x03 \x01(\x05R\rexpiresInDays\"[\n" +
+	"\x12CreatedAccessToken\x12/\n" +
+	"\x04info\x18\x01 \x01(\v2\x1b.godrive.v1.AccessTokenInfoR\x04info\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"F\n" +
+	"\x0fAccessTokenList\x123\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x1b.godrive.v1.AccessTokenInfoR\x06tokens\"*\n" +
+	"\x18RevokeAccessTokenRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xa7\x01\n" +
+	"\vSessionInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x02 \x01(\tR\tuserAgent\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12 \n" +
+	"\flast_seen_at\x18\x05 \x01(\tR\n" +
+	"lastSeenAt\x12\x18\n" +
+	"\acurrent\x18\x06 \x01(\bR\acurrent\"B\n" +
+	"\vSessionList\x123\n" +
+	"\bsessions\x18\x01 \x03(\v2\x17.godrive.v1.SessionInfoR\bsessions\"&\n" +
+	"\x14RevokeSessionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
+	"\x14DeleteAccountRequest\x12\x1a\n" +
+	"\bpassword\x18\x01 \x01(\tR\bpassword\"4\n" +
+	"\x0fAccountDeletion\x12!\n" +
+	"\fdelete_after\x18\x01 \x01(\tR\vdeleteAfter\"%\n" +
+	"\n" +
+	"UserLookup\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\\\n" +
+	"\x03Org\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\".\n" +
+	"\aOrgList\x12#\n" +
+	"\x04orgs\x18\x01 \x03(\v2\x0f.godrive.v1.OrgR\x04orgs\"&\n" +
+	"\x10CreateOrgRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"#\n" +
+	"\n" +
+	"OrgRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\x03R\x05orgId\"k\n" +
+	"\tOrgMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1b\n" +
+	"\tjoined_at\x18\x04 \x01(\tR\bjoinedAt\"@\n" +
+	"\rOrgMemberList\x12/\n" +
+	"\amembers\x18\x01 \x03(\v2\x15.godrive.v1.OrgMemberR\amembers\"V\n" +
+	"\x10OrgMemberRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\x03R\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"V\n" +
+	"\x13InviteMemberRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\x03R\x05orgId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"\xb9\x01\n" +
+	"\rOrgInvitation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\x03R\x05orgId\x12\x19\n" +
+	"\borg_name\x18\x03 \x01(\tR\aorgName\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12\x1d\n" +
+	"\n" +
+	"invited_by\x18\x06 \x01(\tR\tinvitedBy\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\tR\texpiresAt\"P\n" +
+	"\x11OrgInvitationList\x12;\n" +
+	"\vinvitations\x18\x01 \x03(\v2\x19.godrive.v1.OrgInvitationR\vinvitations\"#\n" +
+	"\x11InvitationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x99\x02\n" +
+	"\tAdminUser\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12%\n" +
+	"\x0eemail_verified\x18\x04 \x01(\bR\remailVerified\x12\x1f\n" +
+	"\vmfa_enabled\x18\x05 \x01(\bR\n" +
+	"mfaEnabled\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12!\n" +
+	"\fsuspended_at\x18\a \x01(\tR\vsuspendedAt\x12%\n" +
+	"\x0esuspend_reason\x18\b \x01(\tR\rsuspendReason\x12!\n" +
+	"\fdelete_after\x18\t \x01(\tR\vdeleteAfter\"[\n" +
+	"\x12SearchUsersRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"_\n" +
+	"\x13SearchUsersResponse\x12+\n" +
+	"\x05users\x18\x01 \x03(\v2\x15.godrive.v1.AdminUserR\x05users\x12\x1b\n" +
+	"\tnext_page\x18\x02 \x01(\x05R\bnextPage\"&\n" +
+	"\vUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"d\n" +
+	"\tUserUsage\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1d\n" +
+	"\n" +
+	"file_count\x18\x02 \x01(\x03R\tfileCount\x12\x1f\n" +
+	"\vtotal_bytes\x18\x03 \x01(\x03R\n" +
+	"totalBytes\"E\n" +
+	"\x12SuspendUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"1\n" +
+	"\x19CreateSignupInviteRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"g\n" +
+	"\fSignupInvite\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\tR\texpiresAt\"\x9c\x01\n" +
+	"\n" +
+	"AuditQuery\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\x03R\aactorId\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x14\n" +
+	"\x05since\x18\x03 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\x04 \x01(\tR\x05until\x12\x19\n" +
+	"\bafter_id\x18\x05 \x01(\x03R\aafterId\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"\xcd\x02\n" +
+	"\n" +
+	"AuditEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x0e\n" +
+	"\x02at\x18\x02 \x01(\tR\x02at\x12\x18\n" +
+	"\aservice\x18\x03 \x01(\tR\aservice\x12\x19\n" +
+	"\bactor_id\x18\x04 \x01(\x03R\aactorId\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x16\n" +
+	"\x06target\x18\x06 \x01(\tR\x06target\x12\x0e\n" +
+	"\x02ip\x18\a \x01(\tR\x02ip\x12=\n" +
+	"\adetails\x18\b \x03(\v2#.godrive.v1.AuditEntry.DetailsEntryR\adetails\x12\x1b\n" +
+	"\tprev_hash\x18\t \x01(\tR\bprevHash\x12\x12\n" +
+	"\x04hash\x18\n" +
+	" \x01(\tR\x04hash\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"f\n" +
+	"\x0eAuditEntryList\x120\n" +
+	"\aentries\x18\x01 \x03(\v2\x16.godrive.v1.AuditEntryR\aentries\x12\"\n" +
+	"\rnext_after_id\x18\x02 \x01(\x03R\vnextAfterId\"w\n" +
+	"\x11AuditVerification\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x18\n" +
+	"\achecked\x18\x02 \x01(\x03R\achecked\x12 \n" +
+	"\fbroken_at_id\x18\x03 \x01(\x03R\n" +
+	"brokenAtId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xf4\x01\n" +
 	"\bFileItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
 	"\bowner_id\x18\x02 \x01(\x03R\aownerId\x12\x12\n" +
@@ -1080,7 +5716,9 @@ const file_godrive_v1_godrive_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"version_id\x18\a \x01(\tR\tversionId\"^\n" +
+	"version_id\x18\a \x01(\tR\tversionId\x12\x1b\n" +
+	"\tparent_id\x18\b \x01(\x03R\bparentId\x12\x1b\n" +
+	"\tis_folder\x18\t \x01(\bR\bisFolder\"^\n" +
 	"\x10ListFilesRequest\x12\x19\n" +
 	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
@@ -1109,20 +5747,137 @@ const file_godrive_v1_godrive_proto_rawDesc = "" +
 	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x17\n" +
 	"\afile_id\x18\x02 \x01(\x03R\x06fileId\"$\n" +
 	"\x12DeleteFileResponse\x12\x0e\n" +
-	"\x02ok\x18\x01 \x01(\bR\x02ok\"h\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\"\xb6\x01\n" +
+	"\x14PrepareUploadRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x1b\n" +
+	"\tfolder_id\x18\x02 \x01(\x03R\bfolderId\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04mime\x18\x04 \x01(\tR\x04mime\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes\x12\x17\n" +
+	"\afile_id\x18\x06 \x01(\x03R\x06fileId\"6\n" +
+	"\x15PrepareUploadResponse\x12\x1d\n" +
+	"\n" +
+	"object_key\x18\x01 \x01(\tR\tobjectKey\"\x99\x01\n" +
+	"\x11UpdateFileRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\x03R\x06fileId\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12 \n" +
+	"\tparent_id\x18\x04 \x01(\x03H\x01R\bparentId\x88\x01\x01B\a\n" +
+	"\x05_nameB\f\n" +
+	"\n" +
+	"_parent_id\"a\n" +
+	"\x13CreateFolderRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\x03R\bparentId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"~\n" +
+	"\x13ListChildrenRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x1b\n" +
+	"\tfolder_id\x18\x02 \x01(\x03R\bfolderId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"E\n" +
+	"\x0fFilePathRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\x03R\x06fileId\"6\n" +
+	"\bFilePath\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.godrive.v1.FileItemR\x05items\"C\n" +
+	"\x12ResolvePathRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\"^\n" +
+	"\x10ListTrashRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"q\n" +
+	"\vTrashedItem\x12(\n" +
+	"\x04file\x18\x01 \x01(\v2\x14.godrive.v1.FileItemR\x04file\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x02 \x01(\tR\tdeletedAt\x12\x19\n" +
+	"\bpurge_at\x18\x03 \x01(\tR\apurgeAt\"_\n" +
+	"\x11ListTrashResponse\x12-\n" +
+	"\x05items\x18\x01 \x03(\v2\x17.godrive.v1.TrashedItemR\x05items\x12\x1b\n" +
+	"\tnext_page\x18\x02 \x01(\x05R\bnextPage\"I\n" +
+	"\x13FileVersionsRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\x03R\x06fileId\"\x98\x01\n" +
+	"\vFileVersion\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x01 \x01(\tR\tversionId\x12\x12\n" +
+	"\x04mime\x18\x02 \x01(\tR\x04mime\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x18\n" +
+	"\acurrent\x18\x05 \x01(\bR\acurrent\"C\n" +
+	"\fFileVersions\x123\n" +
+	"\bversions\x18\x01 \x03(\v2\x17.godrive.v1.FileVersionR\bversions\"c\n" +
+	"\x0eVersionRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\x03R\x06fileId\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x03 \x01(\tR\tversionId\"\x85\x01\n" +
+	"\fShareRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\x03R\x06fileId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\"\x92\x01\n" +
+	"\x05Share\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\x03R\x06fileId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"C\n" +
+	"\rSharesRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\x03R\x06fileId\"3\n" +
+	"\x06Shares\x12)\n" +
+	"\x06shares\x18\x01 \x03(\v2\x11.godrive.v1.ShareR\x06shares\"_\n" +
+	"\x0eUnshareRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\x03R\x06fileId\x12\x19\n" +
+	"\bshare_id\x18\x03 \x01(\x03R\ashareId\"a\n" +
+	"\x13SharedWithMeRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"J\n" +
+	"\n" +
+	"SharedItem\x12(\n" +
+	"\x04file\x18\x01 \x01(\v2\x14.godrive.v1.FileItemR\x04file\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"a\n" +
+	"\x14SharedWithMeResponse\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.godrive.v1.SharedItemR\x05items\x12\x1b\n" +
+	"\tnext_page\x18\x02 \x01(\x05R\bnextPage\"B\n" +
+	"\fTrashRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\x03R\x06fileId\")\n" +
+	"\fUsageRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x03R\aownerId\"O\n" +
+	"\rUsageResponse\x12\x1d\n" +
+	"\n" +
+	"file_count\x18\x01 \x01(\x03R\tfileCount\x12\x1f\n" +
+	"\vtotal_bytes\x18\x02 \x01(\x03R\n" +
+	"totalBytes\"\x85\x01\n" +
 	"\x14PresignUploadRequest\x12\x1d\n" +
 	"\n" +
 	"object_key\x18\x01 \x01(\tR\tobjectKey\x12\x12\n" +
 	"\x04mime\x18\x02 \x01(\tR\x04mime\x12\x1d\n" +
 	"\n" +
-	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\"\xce\x01\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x12\x1b\n" +
+	"\tmax_bytes\x18\x04 \x01(\x03R\bmaxBytes\"\xe8\x02\n" +
 	"\x15PresignUploadResponse\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\x12H\n" +
 	"\aheaders\x18\x02 \x03(\v2..godrive.v1.PresignUploadResponse.HeadersEntryR\aheaders\x12\x1d\n" +
 	"\n" +
-	"expires_at\x18\x03 \x01(\tR\texpiresAt\x1a:\n" +
+	"expires_at\x18\x03 \x01(\tR\texpiresAt\x12\x16\n" +
+	"\x06method\x18\x04 \x01(\tR\x06method\x12E\n" +
+	"\x06fields\x18\x05 \x03(\v2-.godrive.v1.PresignUploadResponse.FieldsEntryR\x06fields\x1a:\n" +
 	"\fHeadersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"7\n" +
 	"\x16PresignDownloadRequest\x12\x1d\n" +
 	"\n" +
@@ -1135,16 +5890,83 @@ const file_godrive_v1_godrive_proto_rawDesc = "" +
 	"\n" +
 	"object_key\x18\x01 \x01(\tR\tobjectKey\"&\n" +
 	"\x14DeleteObjectResponse\x12\x0e\n" +
-	"\x02ok\x18\x01 \x01(\bR\x02ok2\xa6\x01\n" +
-	"\vAuthService\x123\n" +
-	"\x06SignUp\x12\x17.godrive.v1.Credentials\x1a\x10.godrive.v1.User\x123\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok2\x81\x0e\n" +
+	"\vAuthService\x125\n" +
+	"\x06SignUp\x12\x19.godrive.v1.SignUpRequest\x1a\x10.godrive.v1.User\x123\n" +
 	"\x05Login\x12\x17.godrive.v1.Credentials\x1a\x11.godrive.v1.Token\x12-\n" +
-	"\x06Verify\x12\x11.godrive.v1.Token\x1a\x10.godrive.v1.User2\xc5\x02\n" +
+	"\x06Verify\x12\x11.godrive.v1.Token\x1a\x10.godrive.v1.User\x128\n" +
+	"\aRefresh\x12\x1a.godrive.v1.RefreshRequest\x1a\x11.godrive.v1.Token\x12.\n" +
+	"\x06Logout\x12\x11.godrive.v1.Token\x1a\x11.godrive.v1.Empty\x129\n" +
+	"\x11LogoutAllSessions\x12\x11.godrive.v1.Token\x1a\x11.godrive.v1.Empty\x12K\n" +
+	"\x14RequestPasswordReset\x12 .godrive.v1.PasswordResetRequest\x1a\x11.godrive.v1.Empty\x12D\n" +
+	"\rResetPassword\x12 .godrive.v1.ResetPasswordRequest\x1a\x11.godrive.v1.Empty\x12@\n" +
+	"\vVerifyEmail\x12\x1e.godrive.v1.VerifyEmailRequest\x1a\x11.godrive.v1.Empty\x12N\n" +
+	"\x12ResendVerification\x12%.godrive.v1.ResendVerificationRequest\x1a\x11.godrive.v1.Empty\x12C\n" +
+	"\x10RequestMagicLink\x12\x1c.godrive.v1.MagicLinkRequest\x1a\x11.godrive.v1.Empty\x12N\n" +
+	"\x16CompleteMagicLinkLogin\x12!.godrive.v1.MagicLinkLoginRequest\x1a\x11.godrive.v1.Token\x12;\n" +
+	"\n" +
+	"EnrollTOTP\x12\x11.godrive.v1.Empty\x1a\x1a.godrive.v1.TOTPEnrollment\x12>\n" +
+	"\vConfirmTOTP\x12\x14.godrive.v1.TOTPCode\x1a\x19.godrive.v1.RecoveryCodes\x12B\n" +
+	"\x10CompleteMFALogin\x12\x1b.godrive.v1.MFALoginRequest\x1a\x11.godrive.v1.Token\x12?\n" +
+	"\rAdminResetMFA\x12\x1b.godrive.v1.ResetMFARequest\x1a\x11.godrive.v1.Empty\x12@\n" +
+	"\vSetUserRole\x12\x1e.godrive.v1.SetUserRoleRequest\x1a\x11.godrive.v1.Empty\x12<\n" +
+	"\x0eBeginOIDCLogin\x12\x11.godrive.v1.Empty\x1a\x17.godrive.v1.OIDCAuthURL\x12@\n" +
+	"\x11CompleteOIDCLogin\x12\x18.godrive.v1.OIDCCallback\x1a\x11.godrive.v1.Token\x12Y\n" +
+	"\x11CreateAccessToken\x12$.godrive.v1.CreateAccessTokenRequest\x1a\x1e.godrive.v1.CreatedAccessToken\x12B\n" +
+	"\x10ListAccessTokens\x12\x11.godrive.v1.Empty\x1a\x1b.godrive.v1.AccessTokenList\x12L\n" +
+	"\x11RevokeAccessToken\x12$.godrive.v1.RevokeAccessTokenRequest\x1a\x11.godrive.v1.Empty\x12:\n" +
+	"\fListSessions\x12\x11.godrive.v1.Empty\x1a\x17.godrive.v1.SessionList\x12D\n" +
+	"\rRevokeSession\x12 .godrive.v1.RevokeSessionRequest\x1a\x11.godrive.v1.Empty\x12N\n" +
+	"\rDeleteAccount\x12 .godrive.v1.DeleteAccountRequest\x1a\x1b.godrive.v1.AccountDeletion\x12=\n" +
+	"\x15CancelAccountDeletion\x12\x11.godrive.v1.Empty\x1a\x11.godrive.v1.Empty\x126\n" +
+	"\n" +
+	"LookupUser\x12\x16.godrive.v1.UserLookup\x1a\x10.godrive.v1.User2\x99\x05\n" +
+	"\n" +
+	"OrgService\x12:\n" +
+	"\tCreateOrg\x12\x1c.godrive.v1.CreateOrgRequest\x1a\x0f.godrive.v1.Org\x122\n" +
+	"\bListOrgs\x12\x11.godrive.v1.Empty\x1a\x13.godrive.v1.OrgList\x12@\n" +
+	"\vListMembers\x12\x16.godrive.v1.OrgRequest\x1a\x19.godrive.v1.OrgMemberList\x12J\n" +
+	"\fInviteMember\x12\x1f.godrive.v1.InviteMemberRequest\x1a\x19.godrive.v1.OrgInvitation\x12C\n" +
+	"\x0fListInvitations\x12\x11.godrive.v1.Empty\x1a\x1d.godrive.v1.OrgInvitationList\x12B\n" +
+	"\x10AcceptInvitation\x12\x1d.godrive.v1.InvitationRequest\x1a\x0f.godrive.v1.Org\x12E\n" +
+	"\x11DeclineInvitation\x12\x1d.godrive.v1.InvitationRequest\x1a\x11.godrive.v1.Empty\x12?\n" +
+	"\fRemoveMember\x12\x1c.godrive.v1.OrgMemberRequest\x1a\x11.godrive.v1.Empty\x12@\n" +
+	"\rSetMemberRole\x12\x1c.godrive.v1.OrgMemberRequest\x1a\x11.godrive.v1.Empty\x12:\n" +
+	"\rIssueOrgToken\x12\x16.godrive.v1.OrgRequest\x1a\x11.godrive.v1.Token2\xfc\x04\n" +
+	"\fAdminService\x12N\n" +
+	"\vSearchUsers\x12\x1e.godrive.v1.SearchUsersRequest\x1a\x1f.godrive.v1.SearchUsersResponse\x12>\n" +
+	"\fGetUserUsage\x12\x17.godrive.v1.UserRequest\x1a\x15.godrive.v1.UserUsage\x12@\n" +
+	"\vSuspendUser\x12\x1e.godrive.v1.SuspendUserRequest\x1a\x11.godrive.v1.Empty\x12;\n" +
+	"\rUnsuspendUser\x12\x17.godrive.v1.UserRequest\x1a\x11.godrive.v1.Empty\x129\n" +
+	"\vForceLogout\x12\x17.godrive.v1.UserRequest\x1a\x11.godrive.v1.Empty\x12B\n" +
+	"\x14TriggerPasswordReset\x12\x17.godrive.v1.UserRequest\x1a\x11.godrive.v1.Empty\x12U\n" +
+	"\x12CreateSignupInvite\x12%.godrive.v1.CreateSignupInviteRequest\x1a\x18.godrive.v1.SignupInvite\x12C\n" +
+	"\rQueryAuditLog\x12\x16.godrive.v1.AuditQuery\x1a\x1a.godrive.v1.AuditEntryList\x12B\n" +
+	"\x0eVerifyAuditLog\x12\x11.godrive.v1.Empty\x1a\x1d.godrive.v1.AuditVerification2\xde\v\n" +
 	"\fFilesService\x12C\n" +
 	"\x04List\x12\x1c.godrive.v1.ListFilesRequest\x1a\x1d.godrive.v1.ListFilesResponse\x12T\n" +
 	"\rConfirmUpload\x12 .godrive.v1.ConfirmUploadRequest\x1a!.godrive.v1.ConfirmUploadResponse\x12G\n" +
 	"\x06Delete\x12\x1d.godrive.v1.DeleteFileRequest\x1a\x1e.godrive.v1.DeleteFileResponse\x12Q\n" +
-	"\x0eGetDownloadURL\x12\x1e.godrive.v1.DownloadURLRequest\x1a\x1f.godrive.v1.DownloadURLResponse2\x95\x02\n" +
+	"\x0eGetDownloadURL\x12\x1e.godrive.v1.DownloadURLRequest\x1a\x1f.godrive.v1.DownloadURLResponse\x12?\n" +
+	"\bGetUsage\x12\x18.godrive.v1.UsageRequest\x1a\x19.godrive.v1.UsageResponse\x12T\n" +
+	"\rPrepareUpload\x12 .godrive.v1.PrepareUploadRequest\x1a!.godrive.v1.PrepareUploadResponse\x12E\n" +
+	"\fCreateFolder\x12\x1f.godrive.v1.CreateFolderRequest\x1a\x14.godrive.v1.FileItem\x12N\n" +
+	"\fListChildren\x12\x1f.godrive.v1.ListChildrenRequest\x1a\x1d.godrive.v1.ListFilesResponse\x12<\n" +
+	"\aGetPath\x12\x1b.godrive.v1.FilePathRequest\x1a\x14.godrive.v1.FilePath\x12C\n" +
+	"\vResolvePath\x12\x1e.godrive.v1.ResolvePathRequest\x1a\x14.godrive.v1.FileItem\x12A\n" +
+	"\n" +
+	"UpdateFile\x12\x1d.godrive.v1.UpdateFileRequest\x1a\x14.godrive.v1.FileItem\x12H\n" +
+	"\tListTrash\x12\x1c.godrive.v1.ListTrashRequest\x1a\x1d.godrive.v1.ListTrashResponse\x129\n" +
+	"\aRestore\x12\x18.godrive.v1.TrashRequest\x1a\x14.godrive.v1.FileItem\x127\n" +
+	"\bPurgeNow\x12\x18.godrive.v1.TrashRequest\x1a\x11.godrive.v1.Empty\x12I\n" +
+	"\fListVersions\x12\x1f.godrive.v1.FileVersionsRequest\x1a\x18.godrive.v1.FileVersions\x12N\n" +
+	"\x0fDownloadVersion\x12\x1a.godrive.v1.VersionRequest\x1a\x1f.godrive.v1.DownloadURLResponse\x12B\n" +
+	"\x0eRestoreVersion\x12\x1a.godrive.v1.VersionRequest\x1a\x14.godrive.v1.FileItem\x128\n" +
+	"\tShareFile\x12\x18.godrive.v1.ShareRequest\x1a\x11.godrive.v1.Share\x12;\n" +
+	"\n" +
+	"ListShares\x12\x19.godrive.v1.SharesRequest\x1a\x12.godrive.v1.Shares\x128\n" +
+	"\aUnshare\x12\x1a.godrive.v1.UnshareRequest\x1a\x11.godrive.v1.Empty\x12U\n" +
+	"\x10ListSharedWithMe\x12\x1f.godrive.v1.SharedWithMeRequest\x1a .godrive.v1.SharedWithMeResponse2\x95\x02\n" +
 	"\x0eStorageService\x12T\n" +
 	"\rPresignUpload\x12 .godrive.v1.PresignUploadRequest\x1a!.godrive.v1.PresignUploadResponse\x12Z\n" +
 	"\x0fPresignDownload\x12\".godrive.v1.PresignDownloadRequest\x1a#.godrive.v1.PresignDownloadResponse\x12Q\n" +
@@ -1162,58 +5984,273 @@ func file_godrive_v1_godrive_proto_rawDescGZIP() []byte {
 	return file_godrive_v1_godrive_proto_rawDescData
 }
 
-var file_godrive_v1_godrive_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_godrive_v1_godrive_proto_msgTypes = make([]protoimpl.MessageInfo, 98)
 var file_godrive_v1_godrive_proto_goTypes = []any{
-	(*Empty)(nil),                   // 0: godrive.v1.Empty
-	(*User)(nil),                    // 1: godrive.v1.User
-	(*Credentials)(nil),             // 2: godrive.v1.Credentials
-	(*Token)(nil),                   // 3: godrive.v1.Token
-	(*FileItem)(nil),                // 4: godrive.v1.FileItem
-	(*ListFilesRequest)(nil),        // 5: godrive.v1.ListFilesRequest
-	(*ListFilesResponse)(nil),       // 6: godrive.v1.ListFilesResponse
-	(*ConfirmUploadRequest)(nil),    // 7: godrive.v1.ConfirmUploadRequest
-	(*ConfirmUploadResponse)(nil),   // 8: godrive.v1.ConfirmUploadResponse
-	(*DownloadURLRequest)(nil),      // 9: godrive.v1.DownloadURLRequest
-	(*DownloadURLResponse)(nil),     // 10: godrive.v1.DownloadURLResponse
-	(*DeleteFileRequest)(nil),       // 11: godrive.v1.DeleteFileRequest
-	(*DeleteFileResponse)(nil),      // 12: godrive.v1.DeleteFileResponse
-	(*PresignUploadRequest)(nil),    // 13: godrive.v1.PresignUploadRequest
-	(*PresignUploadResponse)(nil),   // 14: godrive.v1.PresignUploadResponse
-	(*PresignDownloadRequest)(nil),  // 15: godrive.v1.PresignDownloadRequest
-	(*PresignDownloadResponse)(nil), // 16: godrive.v1.PresignDownloadResponse
-	(*DeleteObjectRequest)(nil),     // 17: godrive.v1.DeleteObjectRequest
-	(*DeleteObjectResponse)(nil),    // 18: godrive.v1.DeleteObjectResponse
-	nil,                             // 19: godrive.v1.PresignUploadResponse.HeadersEntry
+	(*Empty)(nil),                     // 0: godrive.v1.Empty
+	(*User)(nil),                      // 1: godrive.v1.User
+	(*Credentials)(nil),               // 2: godrive.v1.Credentials
+	(*SignUpRequest)(nil),             // 3: godrive.v1.SignUpRequest
+	(*Token)(nil),                     // 4: godrive.v1.Token
+	(*RefreshRequest)(nil),            // 5: godrive.v1.RefreshRequest
+	(*PasswordResetRequest)(nil),      // 6: godrive.v1.PasswordResetRequest
+	(*ResetPasswordRequest)(nil),      // 7: godrive.v1.ResetPasswordRequest
+	(*VerifyEmailRequest)(nil),        // 8: godrive.v1.VerifyEmailRequest
+	(*ResendVerificationRequest)(nil), // 9: godrive.v1.ResendVerificationRequest
+	(*MagicLinkRequest)(nil),          // 10: godrive.v1.MagicLinkRequest
+	(*MagicLinkLoginRequest)(nil),     // 11: godrive.v1.MagicLinkLoginRequest
+	(*TOTPEnrollment)(nil),            // 12: godrive.v1.TOTPEnrollment
+	(*TOTPCode)(nil),                  // 13: godrive.v1.TOTPCode
+	(*RecoveryCodes)(nil),             // 14: godrive.v1.RecoveryCodes
+	(*MFALoginRequest)(nil),           // 15: godrive.v1.MFALoginRequest
+	(*ResetMFARequest)(nil),           // 16: godrive.v1.ResetMFARequest
+	(*SetUserRoleRequest)(nil),        // 17: godrive.v1.SetUserRoleRequest
+	(*OIDCAuthURL)(nil),               // 18: godrive.v1.OIDCAuthURL
+	(*OIDCCallback)(nil),              // 19: godrive.v1.OIDCCallback
+	(*AccessTokenInfo)(nil),           // 20: godrive.v1.AccessTokenInfo
+	(*CreateAccessTokenRequest)(nil),  // 21: godrive.v1.CreateAccessTokenRequest
+	(*CreatedAccessToken)(nil),        // 22: godrive.v1.CreatedAccessToken
+	(*AccessTokenList)(nil),           // 23: godrive.v1.AccessTokenList
+	(*RevokeAccessTokenRequest)(nil),  // 24: godrive.v1.RevokeAccessTokenRequest
+	(*SessionInfo)(nil),               // 25: godrive.v1.SessionInfo
+	(*SessionList)(nil),               // 26: godrive.v1.SessionList
+	(*RevokeSessionRequest)(nil),      // 27: godrive.v1.RevokeSessionRequest
+	(*DeleteAccountRequest)(nil),      // 28: godrive.v1.DeleteAccountRequest
+	(*AccountDeletion)(nil),           // 29: godrive.v1.AccountDeletion
+	(*UserLookup)(nil),                // 30: godrive.v1.UserLookup
+	(*Org)(nil),                       // 31: godrive.v1.Org
+	(*OrgList)(nil),                   // 32: godrive.v1.OrgList
+	(*CreateOrgRequest)(nil),          // 33: godrive.v1.CreateOrgRequest
+	(*OrgRequest)(nil),                // 34: godrive.v1.OrgRequest
+	(*OrgMember)(nil),                 // 35: godrive.v1.OrgMember
+	(*OrgMemberList)(nil),             // 36: godrive.v1.OrgMemberList
+	(*OrgMemberRequest)(nil),          // 37: godrive.v1.OrgMemberRequest
+	(*InviteMemberRequest)(nil),       // 38: godrive.v1.InviteMemberRequest
+	(*OrgInvitation)(nil),             // 39: godrive.v1.OrgInvitation
+	(*OrgInvitationList)(nil),         // 40: godrive.v1.OrgInvitationList
+	(*InvitationRequest)(nil),         // 41: godrive.v1.InvitationRequest
+	(*AdminUser)(nil),                 // 42: godrive.v1.AdminUser
+	(*SearchUsersRequest)(nil),        // 43: godrive.v1.SearchUsersRequest
+	(*SearchUsersResponse)(nil),       // 44: godrive.v1.SearchUsersResponse
+	(*UserRequest)(nil),               // 45: godrive.v1.UserRequest
+	(*UserUsage)(nil),                 // 46: godrive.v1.UserUsage
+	(*SuspendUserRequest)(nil),        // 47: godrive.v1.SuspendUserRequest
+	(*CreateSignupInviteRequest)(nil), // 48: godrive.v1.CreateSignupInviteRequest
+	(*SignupInvite)(nil),              // 49: godrive.v1.SignupInvite
+	(*AuditQuery)(nil),                // 50: godrive.v1.AuditQuery
+	(*AuditEntry)(nil),                // 51: godrive.v1.AuditEntry
+	(*AuditEntryList)(nil),            // 52: godrive.v1.AuditEntryList
+	(*AuditVerification)(nil),         // 53: godrive.v1.AuditVerification
+	(*FileItem)(nil),                  // 54: godrive.v1.FileItem
+	(*ListFilesRequest)(nil),          // 55: godrive.v1.ListFilesRequest
+	(*ListFilesResponse)(nil),         // 56: godrive.v1.ListFilesResponse
+	(*ConfirmUploadRequest)(nil),      // 57: godrive.v1.ConfirmUploadRequest
+	(*ConfirmUploadResponse)(nil),     // 58: godrive.v1.ConfirmUploadResponse
+	(*DownloadURLRequest)(nil),        // 59: godrive.v1.DownloadURLRequest
+	(*DownloadURLResponse)(nil),       // 60: godrive.v1.DownloadURLResponse
+	(*DeleteFileRequest)(nil),         // 61: godrive.v1.DeleteFileRequest
+	(*DeleteFileResponse)(nil),        // 62: godrive.v1.DeleteFileResponse
+	(*PrepareUploadRequest)(nil),      // 63: godrive.v1.PrepareUploadRequest
+	(*PrepareUploadResponse)(nil),     // 64: godrive.v1.PrepareUploadResponse
+	(*UpdateFileRequest)(nil),         // 65: godrive.v1.UpdateFileRequest
+	(*CreateFolderRequest)(nil),       // 66: godrive.v1.CreateFolderRequest
+	(*ListChildrenRequest)(nil),       // 67: godrive.v1.ListChildrenRequest
+	(*FilePathRequest)(nil),           // 68: godrive.v1.FilePathRequest
+	(*FilePath)(nil),                  // 69: godrive.v1.FilePath
+	(*ResolvePathRequest)(nil),        // 70: godrive.v1.ResolvePathRequest
+	(*ListTrashRequest)(nil),          // 71: godrive.v1.ListTrashRequest
+	(*TrashedItem)(nil),               // 72: godrive.v1.TrashedItem
+	(*ListTrashResponse)(nil),         // 73: godrive.v1.ListTrashResponse
+	(*FileVersionsRequest)(nil),       // 74: godrive.v1.FileVersionsRequest
+	(*FileVersion)(nil),               // 75: godrive.v1.FileVersion
+	(*FileVersions)(nil),              // 76: godrive.v1.FileVersions
+	(*VersionRequest)(nil),            // 77: godrive.v1.VersionRequest
+	(*ShareRequest)(nil),              // 78: godrive.v1.ShareRequest
+	(*Share)(nil),                     // 79: godrive.v1.Share
+	(*SharesRequest)(nil),             // 80: godrive.v1.SharesRequest
+	(*Shares)(nil),                    // 81: godrive.v1.Shares
+	(*UnshareRequest)(nil),            // 82: godrive.v1.UnshareRequest
+	(*SharedWithMeRequest)(nil),       // 83: godrive.v1.SharedWithMeRequest
+	(*SharedItem)(nil),                // 84: godrive.v1.SharedItem
+	(*SharedWithMeResponse)(nil),      // 85: godrive.v1.SharedWithMeResponse
+	(*TrashRequest)(nil),              // 86: godrive.v1.TrashRequest
+	(*UsageRequest)(nil),              // 87: godrive.v1.UsageRequest
+	(*UsageResponse)(nil),             // 88: godrive.v1.UsageResponse
+	(*PresignUploadRequest)(nil),      // 89: godrive.v1.PresignUploadRequest
+	(*PresignUploadResponse)(nil),     // 90: godrive.v1.PresignUploadResponse
+	(*PresignDownloadRequest)(nil),    // 91: godrive.v1.PresignDownloadRequest
+	(*PresignDownloadResponse)(nil),   // 92: godrive.v1.PresignDownloadResponse
+	(*DeleteObjectRequest)(nil),       // 93: godrive.v1.DeleteObjectRequest
+	(*DeleteObjectResponse)(nil),      // 94: godrive.v1.DeleteObjectResponse
+	nil,                               // 95: godrive.v1.AuditEntry.DetailsEntry
+	nil,                               // 96: godrive.v1.PresignUploadResponse.HeadersEntry
+	nil,                               // 97: godrive.v1.PresignUploadResponse.FieldsEntry
 }
 var file_godrive_v1_godrive_proto_depIdxs = []int32{
-	4,  // 0: godrive.v1.ListFilesResponse.files:type_name -> godrive.v1.FileItem
-	4,  // 1: godrive.v1.ConfirmUploadResponse.file:type_name -> godrive.v1.FileItem
-	19, // 2: godrive.v1.PresignUploadResponse.headers:type_name -> godrive.v1.PresignUploadResponse.HeadersEntry
-	2,  // 3: godrive.v1.AuthService.SignUp:input_type -> godrive.v1.Credentials
-	2,  // 4: godrive.v1.AuthService.Login:input_type -> godrive.v1.Credentials
-	3,  // 5: godrive.v1.AuthService.Verify:input_type -> godrive.v1.Token
-	5,  // 6: godrive.v1.FilesService.List:input_type -> godrive.v1.ListFilesRequest
-	7,  // 7: godrive.v1.FilesService.ConfirmUpload:input_type -> godrive.v1.ConfirmUploadRequest
-	11, // 8: godrive.v1.FilesService.Delete:input_type -> godrive.v1.DeleteFileRequest
-	9,  // 9: godrive.v1.FilesService.GetDownloadURL:input_type -> godrive.v1.DownloadURLRequest
-	13, // 10: godrive.v1.StorageService.PresignUpload:input_type -> godrive.v1.PresignUploadRequest
-	15, // 11: godrive.v1.StorageService.PresignDownload:input_type -> godrive.v1.PresignDownloadRequest
-	17, // 12: godrive.v1.StorageService.DeleteObject:input_type -> godrive.v1.DeleteObjectRequest
-	1,  // 13: godrive.v1.AuthService.SignUp:output_type -> godrive.v1.User
-	3,  // 14: godrive.v1.AuthService.Login:output_type -> godrive.v1.Token
-	1,  // 15: godrive.v1.AuthService.Verify:output_type -> godrive.v1.User
-	6,  // 16: godrive.v1.FilesService.List:output_type -> godrive.v1.ListFilesResponse
-	8,  // 17: godrive.v1.FilesService.ConfirmUpload:output_type -> godrive.v1.ConfirmUploadResponse
-	12, // 18: godrive.v1.FilesService.Delete:output_type -> godrive.v1.DeleteFileResponse
-	10, // 19: godrive.v1.FilesService.GetDownloadURL:output_type -> godrive.v1.DownloadURLResponse
-	14, // 20: godrive.v1.StorageService.PresignUpload:output_type -> godrive.v1.PresignUploadResponse
-	16, // 21: godrive.v1.StorageService.PresignDownload:output_type -> godrive.v1.PresignDownloadResponse
-	18, // 22: godrive.v1.StorageService.DeleteObject:output_type -> godrive.v1.DeleteObjectResponse
-	13, // [13:23] is the sub-list for method output_type
-	3,  // [3:13] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	20, // 0: godrive.v1.CreatedAccessToken.info:type_name -> godrive.v1.AccessTokenInfo
+	20, // 1: godrive.v1.AccessTokenList.tokens:type_name -> godrive.v1.AccessTokenInfo
+	25, // 2: godrive.v1.SessionList.sessions:type_name -> godrive.v1.SessionInfo
+	31, // 3: godrive.v1.OrgList.orgs:type_name -> godrive.v1.Org
+	35, // 4: godrive.v1.OrgMemberList.members:type_name -> godrive.v1.OrgMember
+	39, // 5: godrive.v1.OrgInvitationList.invitations:type_name -> godrive.v1.OrgInvitation
+	42, // 6: godrive.v1.SearchUsersResponse.users:type_name -> godrive.v1.AdminUser
+	95, // 7: godrive.v1.AuditEntry.details:type_name -> godrive.v1.AuditEntry.DetailsEntry
+	51, // 8: godrive.v1.AuditEntryList.entries:type_name -> godrive.v1.AuditEntry
+	54, // 9: godrive.v1.ListFilesResponse.files:type_name -> godrive.v1.FileItem
+	54, // 10: godrive.v1.ConfirmUploadResponse.file:type_name -> godrive.v1.FileItem
+	54, // 11: godrive.v1.FilePath.items:type_name -> godrive.v1.FileItem
+	54, // 12: godrive.v1.TrashedItem.file:type_name -> godrive.v1.FileItem
+	72, // 13: godrive.v1.ListTrashResponse.items:type_name -> godrive.v1.TrashedItem
+	75, // 14: godrive.v1.FileVersions.versions:type_name -> godrive.v1.FileVersion
+	79, // 15: godrive.v1.Shares.shares:type_name -> godrive.v1.Share
+	54, // 16: godrive.v1.SharedItem.file:type_name -> godrive.v1.FileItem
+	84, // 17: godrive.v1.SharedWithMeResponse.items:type_name -> godrive.v1.SharedItem
+	96, // 18: godrive.v1.PresignUploadResponse.headers:type_name -> godrive.v1.PresignUploadResponse.HeadersEntry
+	97, // 19: godrive.v1.PresignUploadResponse.fields:type_name -> godrive.v1.PresignUploadResponse.FieldsEntry
+	3,  // 20: godrive.v1.AuthService.SignUp:input_type -> godrive.v1.SignUpRequest
+	2,  // 21: godrive.v1.AuthService.Login:input_type -> godrive.v1.Credentials
+	4,  // 22: godrive.v1.AuthService.Verify:input_type -> godrive.v1.Token
+	5,  // 23: godrive.v1.AuthService.Refresh:input_type -> godrive.v1.RefreshRequest
+	4,  // 24: godrive.v1.AuthService.Logout:input_type -> godrive.v1.Token
+	4,  // 25: godrive.v1.AuthService.LogoutAllSessions:input_type -> godrive.v1.Token
+	6,  // 26: godrive.v1.AuthService.RequestPasswordReset:input_type -> godrive.v1.PasswordResetRequest
+	7,  // 27: godrive.v1.AuthService.ResetPassword:input_type -> godrive.v1.ResetPasswordRequest
+	8,  // 28: godrive.v1.AuthService.VerifyEmail:input_type -> godrive.v1.VerifyEmailRequest
+	9,  // 29: godrive.v1.AuthService.ResendVerification:input_type -> godrive.v1.ResendVerificationRequest
+	10, // 30: godrive.v1.AuthService.RequestMagicLink:input_type -> godrive.v1.MagicLinkRequest
+	11, // 31: godrive.v1.AuthService.CompleteMagicLinkLogin:input_type -> godrive.v1.MagicLinkLoginRequest
+	0,  // 32: godrive.v1.AuthService.EnrollTOTP:input_type -> godrive.v1.Empty
+	13, // 33: godrive.v1.AuthService.ConfirmTOTP:input_type -> godrive.v1.TOTPCode
+	15, // 34: godrive.v1.AuthService.CompleteMFALogin:input_type -> godrive.v1.MFALoginRequest
+	16, // 35: godrive.v1.AuthService.AdminResetMFA:input_type -> godrive.v1.ResetMFARequest
+	17, // 36: godrive.v1.AuthService.SetUserRole:input_type -> godrive.v1.SetUserRoleRequest
+	0,  // 37: godrive.v1.AuthService.BeginOIDCLogin:input_type -> godrive.v1.Empty
+	19, // 38: godrive.v1.AuthService.CompleteOIDCLogin:input_type -> godrive.v1.OIDCCallback
+	21, // 39: godrive.v1.AuthService.CreateAccessToken:input_type -> godrive.v1.CreateAccessTokenRequest
+	0,  // 40: godrive.v1.AuthService.ListAccessTokens:input_type -> godrive.v1.Empty
+	24, // 41: godrive.v1.AuthService.RevokeAccessToken:input_type -> godrive.v1.RevokeAccessTokenRequest
+	0,  // 42: godrive.v1.AuthService.ListSessions:input_type -> godrive.v1.Empty
+	27, // 43: godrive.v1.AuthService.RevokeSession:input_type -> godrive.v1.RevokeSessionRequest
+	28, // 44: godrive.v1.AuthService.DeleteAccount:input_type -> godrive.v1.DeleteAccountRequest
+	0,  // 45: godrive.v1.AuthService.CancelAccountDeletion:input_type -> godrive.v1.Empty
+	30, // 46: godrive.v1.AuthService.LookupUser:input_type -> godrive.v1.UserLookup
+	33, // 47: godrive.v1.OrgService.CreateOrg:input_type -> godrive.v1.CreateOrgRequest
+	0,  // 48: godrive.v1.OrgService.ListOrgs:input_type -> godrive.v1.Empty
+	34, // 49: godrive.v1.OrgService.ListMembers:input_type -> godrive.v1.OrgRequest
+	38, // 50: godrive.v1.OrgService.InviteMember:input_type -> godrive.v1.InviteMemberRequest
+	0,  // 51: godrive.v1.OrgService.ListInvitations:input_type -> godrive.v1.Empty
+	41, // 52: godrive.v1.OrgService.AcceptInvitation:input_type -> godrive.v1.InvitationRequest
+	41, // 53: godrive.v1.OrgService.DeclineInvitation:input_type -> godrive.v1.InvitationRequest
+	37, // 54: godrive.v1.OrgService.RemoveMember:input_type -> godrive.v1.OrgMemberRequest
+	37, // 55: godrive.v1.OrgService.SetMemberRole:input_type -> godrive.v1.OrgMemberRequest
+	34, // 56: godrive.v1.OrgService.IssueOrgToken:input_type -> godrive.v1.OrgRequest
+	43, // 57: godrive.v1.AdminService.SearchUsers:input_type -> godrive.v1.SearchUsersRequest
+	45, // 58: godrive.v1.AdminService.GetUserUsage:input_type -> godrive.v1.UserRequest
+	47, // 59: godrive.v1.AdminService.SuspendUser:input_type -> godrive.v1.SuspendUserRequest
+	45, // 60: godrive.v1.AdminService.UnsuspendUser:input_type -> godrive.v1.UserRequest
+	45, // 61: godrive.v1.AdminService.ForceLogout:input_type -> godrive.v1.UserRequest
+	45, // 62: godrive.v1.AdminService.TriggerPasswordReset:input_type -> godrive.v1.UserRequest
+	48, // 63: godrive.v1.AdminService.CreateSignupInvite:input_type -> godrive.v1.CreateSignupInviteRequest
+	50, // 64: godrive.v1.AdminService.QueryAuditLog:input_type -> godrive.v1.AuditQuery
+	0,  // 65: godrive.v1.AdminService.VerifyAuditLog:input_type -> godrive.v1.Empty
+	55, // 66: godrive.v1.FilesService.List:input_type -> godrive.v1.ListFilesRequest
+	57, // 67: godrive.v1.FilesService.ConfirmUpload:input_type -> godrive.v1.ConfirmUploadRequest
+	61, // 68: godrive.v1.FilesService.Delete:input_type -> godrive.v1.DeleteFileRequest
+	59, // 69: godrive.v1.FilesService.GetDownloadURL:input_type -> godrive.v1.DownloadURLRequest
+	87, // 70: godrive.v1.FilesService.GetUsage:input_type -> godrive.v1.UsageRequest
+	63, // 71: godrive.v1.FilesService.PrepareUpload:input_type -> godrive.v1.PrepareUploadRequest
+	66, // 72: godrive.v1.FilesService.CreateFolder:input_type -> godrive.v1.CreateFolderRequest
+	67, // 73: godrive.v1.FilesService.ListChildren:input_type -> godrive.v1.ListChildrenRequest
+	68, // 74: godrive.v1.FilesService.GetPath:input_type -> godrive.v1.FilePathRequest
+	70, // 75: godrive.v1.FilesService.ResolvePath:input_type -> godrive.v1.ResolvePathRequest
+	65, // 76: godrive.v1.FilesService.UpdateFile:input_type -> godrive.v1.UpdateFileRequest
+	71, // 77: godrive.v1.FilesService.ListTrash:input_type -> godrive.v1.ListTrashRequest
+	86, // 78: godrive.v1.FilesService.Restore:input_type -> godrive.v1.TrashRequest
+	86, // 79: godrive.v1.FilesService.PurgeNow:input_type -> godrive.v1.TrashRequest
+	74, // 80: godrive.v1.FilesService.ListVersions:input_type -> godrive.v1.FileVersionsRequest
+	77, // 81: godrive.v1.FilesService.DownloadVersion:input_type -> godrive.v1.VersionRequest
+	77, // 82: godrive.v1.FilesService.RestoreVersion:input_type -> godrive.v1.VersionRequest
+	78, // 83: godrive.v1.FilesService.ShareFile:input_type -> godrive.v1.ShareRequest
+	80, // 84: godrive.v1.FilesService.ListShares:input_type -> godrive.v1.SharesRequest
+	82, // 85: godrive.v1.FilesService.Unshare:input_type -> godrive.v1.UnshareRequest
+	83, // 86: godrive.v1.FilesService.ListSharedWithMe:input_type -> godrive.v1.SharedWithMeRequest
+	89, // 87: godrive.v1.StorageService.PresignUpload:input_type -> godrive.v1.PresignUploadRequest
+	91, // 88: godrive.v1.StorageService.PresignDownload:input_type -> godrive.v1.PresignDownloadRequest
+	93, // 89: godrive.v1.StorageService.DeleteObject:input_type -> godrive.v1.DeleteObjectRequest
+	1,  // 90: godrive.v1.AuthService.SignUp:output_type -> godrive.v1.User
+	4,  // 91: godrive.v1.AuthService.Login:output_type -> godrive.v1.Token
+	1,  // 92: godrive.v1.AuthService.Verify:output_type -> godrive.v1.User
+	4,  // 93: godrive.v1.AuthService.Refresh:output_type -> godrive.v1.Token
+	0,  // 94: godrive.v1.AuthService.Logout:output_type -> godrive.v1.Empty
+	0,  // 95: godrive.v1.AuthService.LogoutAllSessions:output_type -> godrive.v1.Empty
+	0,  // 96: godrive.v1.AuthService.RequestPasswordReset:output_type -> godrive.v1.Empty
+	0,  // 97: godrive.v1.AuthService.ResetPassword:output_type -> godrive.v1.Empty
+	0,  // 98: godrive.v1.AuthService.VerifyEmail:output_type -> godrive.v1.Empty
+	0,  // 99: godrive.v1.AuthService.ResendVerification:output_type -> godrive.v1.Empty
+	0,  // 100: godrive.v1.AuthService.RequestMagicLink:output_type -> godrive.v1.Empty
+	4,  // 101: godrive.v1.AuthService.CompleteMagicLinkLogin:output_type -> godrive.v1.Token
+	12, // 102: godrive.v1.AuthService.EnrollTOTP:output_type -> godrive.v1.TOTPEnrollment
+	14, // 103: godrive.v1.AuthService.ConfirmTOTP:output_type -> godrive.v1.RecoveryCodes
+	4,  // 104: godrive.v1.AuthService.CompleteMFALogin:output_type -> godrive.v1.Token
+	0,  // 105: godrive.v1.AuthService.AdminResetMFA:output_type -> godrive.v1.Empty
+	0,  // 106: godrive.v1.AuthService.SetUserRole:output_type -> godrive.v1.Empty
+	18, // 107: godrive.v1.AuthService.BeginOIDCLogin:output_type -> godrive.v1.OIDCAuthURL
+	4,  // 108: godrive.v1.AuthService.CompleteOIDCLogin:output_type -> godrive.v1.Token
+	22, // 109: godrive.v1.AuthService.CreateAccessToken:output_type -> godrive.v1.CreatedAccessToken
+	23, // 110: godrive.v1.AuthService.ListAccessTokens:output_type -> godrive.v1.AccessTokenList
+	0,  // 111: godrive.v1.AuthService.RevokeAccessToken:output_type -> godrive.v1.Empty
+	26, // 112: godrive.v1.AuthService.ListSessions:output_type -> godrive.v1.SessionList
+	0,  // 113: godrive.v1.AuthService.RevokeSession:output_type -> godrive.v1.Empty
+	29, // 114: godrive.v1.AuthService.DeleteAccount:output_type -> godrive.v1.AccountDeletion
+	0,  // 115: godrive.v1.AuthService.CancelAccountDeletion:output_type -> godrive.v1.Empty
+	1,  // 116: godrive.v1.AuthService.LookupUser:output_type -> godrive.v1.User
+	31, // 117: godrive.v1.OrgService.CreateOrg:output_type -> godrive.v1.Org
+	32, // 118: godrive.v1.OrgService.ListOrgs:output_type -> godrive.v1.OrgList
+	36, // 119: godrive.v1.OrgService.ListMembers:output_type -> godrive.v1.OrgMemberList
+	39, // 120: godrive.v1.OrgService.InviteMember:output_type -> godrive.v1.OrgInvitation
+	40, // 121: godrive.v1.OrgService.ListInvitations:output_type -> godrive.v1.OrgInvitationList
+	31, // 122: godrive.v1.OrgService.AcceptInvitation:output_type -> godrive.v1.Org
+	0,  // 123: godrive.v1.OrgService.DeclineInvitation:output_type -> godrive.v1.Empty
+	0,  // 124: godrive.v1.OrgService.RemoveMember:output_type -> godrive.v1.Empty
+	0,  // 125: godrive.v1.OrgService.SetMemberRole:output_type -> godrive.v1.Empty
+	4,  // 126: godrive.v1.OrgService.IssueOrgToken:output_type -> godrive.v1.Token
+	44, // 127: godrive.v1.AdminService.SearchUsers:output_type -> godrive.v1.SearchUsersResponse
+	46, // 128: godrive.v1.AdminService.GetUserUsage:output_type -> godrive.v1.UserUsage
+	0,  // 129: godrive.v1.AdminService.SuspendUser:output_type -> godrive.v1.Empty
+	0,  // 130: godrive.v1.AdminService.UnsuspendUser:output_type -> godrive.v1.Empty
+	0,  // 131: godrive.v1.AdminService.ForceLogout:output_type -> godrive.v1.Empty
+	0,  // 132: godrive.v1.AdminService.TriggerPasswordReset:output_type -> godrive.v1.Empty
+	49, // 133: godrive.v1.AdminService.CreateSignupInvite:output_type -> godrive.v1.SignupInvite
+	52, // 134: godrive.v1.AdminService.QueryAuditLog:output_type -> godrive.v1.AuditEntryList
+	53, // 135: godrive.v1.AdminService.VerifyAuditLog:output_type -> godrive.v1.AuditVerification
+	56, // 136: godrive.v1.FilesService.List:output_type -> godrive.v1.ListFilesResponse
+	58, // 137: godrive.v1.FilesService.ConfirmUpload:output_type -> godrive.v1.ConfirmUploadResponse
+	62, // 138: godrive.v1.FilesService.Delete:output_type -> godrive.v1.DeleteFileResponse
+	60, // 139: godrive.v1.FilesService.GetDownloadURL:output_type -> godrive.v1.DownloadURLResponse
+	88, // 140: godrive.v1.FilesService.GetUsage:output_type -> godrive.v1.UsageResponse
+	64, // 141: godrive.v1.FilesService.PrepareUpload:output_type -> godrive.v1.PrepareUploadResponse
+	54, // 142: godrive.v1.FilesService.CreateFolder:output_type -> godrive.v1.FileItem
+	56, // 143: godrive.v1.FilesService.ListChildren:output_type -> godrive.v1.ListFilesResponse
+	69, // 144: godrive.v1.FilesService.GetPath:output_type -> godrive.v1.FilePath
+	54, // 145: godrive.v1.FilesService.ResolvePath:output_type -> godrive.v1.FileItem
+	54, // 146: godrive.v1.FilesService.UpdateFile:output_type -> godrive.v1.FileItem
+	73, // 147: godrive.v1.FilesService.ListTrash:output_type -> godrive.v1.ListTrashResponse
+	54, // 148: godrive.v1.FilesService.Restore:output_type -> godrive.v1.FileItem
+	0,  // 149: godrive.v1.FilesService.PurgeNow:output_type -> godrive.v1.Empty
+	76, // 150: godrive.v1.FilesService.ListVersions:output_type -> godrive.v1.FileVersions
+	60, // 151: godrive.v1.FilesService.DownloadVersion:output_type -> godrive.v1.DownloadURLResponse
+	54, // 152: godrive.v1.FilesService.RestoreVersion:output_type -> godrive.v1.FileItem
+	79, // 153: godrive.v1.FilesService.ShareFile:output_type -> godrive.v1.Share
+	81, // 154: godrive.v1.FilesService.ListShares:output_type -> godrive.v1.Shares
+	0,  // 155: godrive.v1.FilesService.Unshare:output_type -> godrive.v1.Empty
+	85, // 156: godrive.v1.FilesService.ListSharedWithMe:output_type -> godrive.v1.SharedWithMeResponse
+	90, // 157: godrive.v1.StorageService.PresignUpload:output_type -> godrive.v1.PresignUploadResponse
+	92, // 158: godrive.v1.StorageService.PresignDownload:output_type -> godrive.v1.PresignDownloadResponse
+	94, // 159: godrive.v1.StorageService.DeleteObject:output_type -> godrive.v1.DeleteObjectResponse
+	90, // [90:160] is the sub-list for method output_type
+	20, // [20:90] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_godrive_v1_godrive_proto_init() }
@@ -1221,15 +6258,16 @@ func file_godrive_v1_godrive_proto_init() {
 	if File_godrive_v1_godrive_proto != nil {
 		return
 	}
+	file_godrive_v1_godrive_proto_msgTypes[65].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_godrive_v1_godrive_proto_rawDesc), len(file_godrive_v1_godrive_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   20,
+			NumMessages:   98,
 			NumExtensions: 0,
-			NumServices:   3,
+			NumServices:   5,
 		},
 		GoTypes:           file_godrive_v1_godrive_proto_goTypes,
 		DependencyIndexes: file_godrive_v1_godrive_proto_depIdxs,
@@ -0,0 +1,24 @@
+#!/bin/sh
+# Creates a dev CA and one certificate per service in ./certs for the
+# mutual TLS between services. Each certificate's DNS name is the service
+# name, which is both the host other services dial and the identity
+# servers check. Re-running it keeps the CA and reissues the service
+# certificates; the services pick them up without a restart.
+set -eu
+cd "$(dirname "$0")/.."
+mkdir -p certs
+cd certs
+
+if [ ! -f ca.pem ]; then
+  openssl req -x509 -newkey ec -pkeyopt ec_paramgen_curve:P-256 -nodes \
+    -keyout ca-key.pem -out ca.pem -days 3650 -subj "/CN=godrive internal CA"
+fi
+
+for svc in auth files storage ingest janitor gateway; do
+  openssl req -newkey ec -pkeyopt ec_paramgen_curve:P-256 -nodes \
+    -keyout "$svc-key.pem" -out "$svc.csr" -subj "/CN=$svc"
+  printf 'subjectAltName=DNS:%s\nextendedKeyUsage=serverAuth,clientAuth\n' "$svc" > "$svc.ext"
+  openssl x509 -req -in "$svc.csr" -CA ca.pem -CAkey ca-key.pem -CAcreateserial \
+    -out "$svc.pem" -days 90 -extfile "$svc.ext"
+  rm "$svc.csr" "$svc.ext"
+done
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeleteAccount schedules the caller's account for erasure once the
+// cooling-off period (ACCOUNT_DELETION_DELAY) is over. The janitor then
+// purges the account's files and removes the user row. Asking again keeps
+// the original date. Every session and personal access token is revoked
+// now; logging in again is how the deletion is cancelled.
+func (s *server) DeleteAccount(ctx context.Context, in *gv1.DeleteAccountRequest) (*gv1.AccountDeletion, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var email, hash string
+	err = s.db.QueryRow(ctx,
+		`SELECT email, password_hash FROM users WHERE id = $1`,
+		c.UID,
+	).Scan(&email, &hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Accounts created through SSO have no password; they can set one with
+	// a password reset first.
+	if hash == "" {
+		return nil, status.Error(codes.FailedPrecondition, "set a password before deleting the account")
+	}
+
+	// A stolen token mustn't make this a way around the login throttle.
+	keys := loginKeys(ctx, email)
+	if err := s.checkThrottle(ctx, keys); err != nil {
+		return nil, err
+	}
+	if ok, _ := s.verifyPassword(hash, in.Password); !ok {
+		s.recordLoginFailure(ctx, keys)
+		return nil, status.Error(codes.PermissionDenied, "wrong password")
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var after time.Time
+	err = tx.QueryRow(ctx, `
+UPDATE users
+SET delete_after = COALESCE(delete_after, $2)
+WHERE id = $1
+RETURNING delete_after`, c.UID, time.Now().Add(s.deletionDelay)).Scan(&after)
+	if err != nil {
+		return nil, err
+	}
+
+	ev, err := s.revokeUserTokens(ctx, tx, c.UID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.Exec(ctx,
+		`UPDATE personal_access_tokens SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`,
+		c.UID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	s.publishRevocation(ev)
+
+	log.Printf("account deletion scheduled: uid=%d after=%s", c.UID, after.UTC().Format(time.RFC3339))
+	s.record(ctx, c.UID, "auth.account_deletion_scheduled", userTarget(c.UID),
+		map[string]string{"delete_after": after.UTC().Format(time.RFC3339)})
+
+	s.deliver(mail{
+		To:      email,
+		Subject: "Your GoDrive account will be deleted",
+		Body: fmt.Sprintf(
+			"Your GoDrive account and all of its files will be permanently deleted on %s.\n\n"+
+				"Changed your mind? Log in before then and cancel the deletion.\n",
+			after.UTC().Format("2006-01-02 15:04 MST")),
+	})
+
+	return &gv1.AccountDeletion{DeleteAfter: after.UTC().Format(time.RFC3339)}, nil
+}
+
+// CancelAccountDeletion keeps the caller's account during the cooling-off period.
+func (s *server) CancelAccountDeletion(ctx context.Context, _ *gv1.Empty) (*gv1.Empty, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ct, err := s.db.Exec(ctx,
+		`UPDATE users SET delete_after = NULL WHERE id = $1 AND delete_after IS NOT NULL`,
+		c.UID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if ct.RowsAffected() == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "no deletion pending")
+	}
+
+	log.Printf("account deletion cancelled: uid=%d", c.UID)
+	s.record(ctx, c.UID, "auth.account_deletion_cancelled", userTarget(c.UID), nil)
+
+	return &gv1.Empty{}, nil
+}
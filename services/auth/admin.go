@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errSuspended is returned wherever a suspended account tries to get tokens.
+var errSuspended = status.Error(codes.PermissionDenied, "account suspended")
+
+// adminServer implements gv1.AdminServiceServer. Access is enforced by the
+// rbac interceptor (see rolePolicy), so handlers don't check roles themselves.
+type adminServer struct {
+	gv1.UnimplementedAdminServiceServer
+	*server
+}
+
+// SearchUsers lists users whose email contains the query, newest first.
+func (s *adminServer) SearchUsers(ctx context.Context, in *gv1.SearchUsersRequest) (*gv1.SearchUsersResponse, error) {
+	page := in.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := in.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 50
+	}
+	offset := (page - 1) * pageSize
+
+	// Escape LIKE wildcards so the query is a plain substring match.
+	q := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.ToLower(strings.TrimSpace(in.Query)))
+
+	rows, err := s.db.Query(ctx, `
+SELECT id, email, role, email_verified_at IS NOT NULL, totp_enabled_at IS NOT NULL,
+       created_at, suspended_at, COALESCE(suspend_reason, ''), delete_after
+FROM users
+WHERE email LIKE '%' || $1 || '%'
+ORDER BY id DESC
+LIMIT $2 OFFSET $3`, q, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := &gv1.SearchUsersResponse{}
+	for rows.Next() {
+		var (
+			u                      gv1.AdminUser
+			created                time.Time
+			suspended, deleteAfter *time.Time
+		)
+		err := rows.Scan(&u.Id, &u.Email, &u.Role, &u.EmailVerified, &u.MfaEnabled,
+			&created, &suspended, &u.SuspendReason, &deleteAfter)
+		if err != nil {
+			return nil, err
+		}
+		u.CreatedAt = created.UTC().Format(time.RFC3339)
+		u.SuspendedAt = fmtTime(suspended)
+		u.DeleteAfter = fmtTime(deleteAfter)
+		out.Users = append(out.Users, &u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if int32(len(out.Users)) == pageSize {
+		out.NextPage = page + 1
+	}
+
+	return out, nil
+}
+
+// GetUserUsage reports how many files a user stores and their total size,
+// as counted by the files service.
+func (s *adminServer) GetUserUsage(ctx context.Context, in *gv1.UserRequest) (*gv1.UserUsage, error) {
+	if err := s.userExists(ctx, in.UserId); err != nil {
+		return nil, err
+	}
+
+	u, err := s.files.GetUsage(ctx, &gv1.UsageRequest{OwnerId: in.UserId})
+	if err != nil {
+		return nil, err
+	}
+
+	return &gv1.UserUsage{UserId: in.UserId, FileCount: u.FileCount, TotalBytes: u.TotalBytes}, nil
+}
+
+// SuspendUser blocks an account: no new tokens, and the existing ones are
+// revoked (Verify also rejects suspended users outright).
+func (s *adminServer) SuspendUser(ctx context.Context, in *gv1.SuspendUserRequest) (*gv1.Empty, error) {
+	by := s.actor(ctx)
+	if by == in.UserId {
+		return nil, status.Error(codes.FailedPrecondition, "cannot suspend yourself")
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	ct, err := tx.Exec(ctx, `
+UPDATE users
+SET suspended_at = COALESCE(suspended_at, now()),
+    suspend_reason = $2
+WHERE id = $1`, in.UserId, strings.TrimSpace(in.Reason))
+	if err != nil {
+		return nil, err
+	}
+	if ct.RowsAffected() == 0 {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	ev, err := s.revokeUserTokens(ctx, tx, in.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	s.publishRevocation(ev)
+
+	log.Printf("user suspended: uid=%d by=%d", in.UserId, by)
+	s.record(ctx, by, "auth.user_suspended", userTarget(in.UserId), map[string]string{"reason": in.Reason})
+
+	return &gv1.Empty{}, nil
+}
+
+// UnsuspendUser lifts a suspension. The user has to log in again.
+func (s *adminServer) UnsuspendUser(ctx context.Context, in *gv1.UserRequest) (*gv1.Empty, error) {
+	by := s.actor(ctx)
+
+	ct, err := s.db.Exec(ctx,
+		`UPDATE users SET suspended_at = NULL, suspend_reason = NULL WHERE id = $1`,
+		in.UserId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if ct.RowsAffected() == 0 {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	log.Printf("user unsuspended: uid=%d by=%d", in.UserId, by)
+	s.record(ctx, by, "auth.user_unsuspended", userTarget(in.UserId), nil)
+
+	return &gv1.Empty{}, nil
+}
+
+// ForceLogout revokes every session and token a user holds.
+func (s *adminServer) ForceLogout(ctx context.Context, in *gv1.UserRequest) (*gv1.Empty, error) {
+	by := s.actor(ctx)
+
+	if err := s.userExists(ctx, in.UserId); err != nil {
+		return nil, err
+	}
+
+	ev, err := s.revokeUserTokens(ctx, s.db, in.UserId)
+	if err != nil {
+		return nil, err
+	}
+	s.publishRevocation(ev)
+
+	log.Printf("user logged out by admin: uid=%d by=%d", in.UserId, by)
+	s.record(ctx, by, "auth.force_logout", userTarget(in.UserId), nil)
+
+	return &gv1.Empty{}, nil
+}
+
+// TriggerPasswordReset mails the user a reset link, as if they had asked.
+func (s *adminServer) TriggerPasswordReset(ctx context.Context, in *gv1.UserRequest) (*gv1.Empty, error) {
+	by := s.actor(ctx)
+
+	var email string
+	err := s.db.QueryRow(ctx, `SELECT email FROM users WHERE id = $1`, in.UserId).Scan(&email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sendPasswordReset(ctx, in.UserId, email); err != nil {
+		return nil, err
+	}
+
+	log.Printf("password reset sent by admin: uid=%d by=%d", in.UserId, by)
+	s.record(ctx, by, "auth.password_reset_sent", userTarget(in.UserId), nil)
+
+	return &gv1.Empty{}, nil
+}
+
+func (s *adminServer) userExists(ctx context.Context, uid int64) error {
+	var ok bool
+	if err := s.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`, uid).Scan(&ok); err != nil {
+		return err
+	}
+	if !ok {
+		return status.Error(codes.NotFound, "user not found")
+	}
+
+	return nil
+}
+
+// actor is the user ID behind an admin call, for the logs. The interceptor
+// has already authenticated the caller.
+func (s *server) actor(ctx context.Context) int64 {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return 0
+	}
+
+	return c.UID
+}
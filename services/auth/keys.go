@@ -0,0 +1,192 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// keyring holds the Ed25519 keys we sign access tokens with.
+//
+// Keys live in a directory as PKCS#8 PEM files; the file name (minus .pem) is
+// the key ID that goes into the JWT "kid" header. Every key in the directory
+// is published in the JWKS, so rotation is:
+//  1. add the new key file (it is published, and becomes active if it sorts last),
+//  2. wait at least one access-token lifetime,
+//  3. delete the old key file.
+type keyring struct {
+	dir       string // empty means "dev mode": one ephemeral in-memory key
+	activeKID string // optional override from JWT_ACTIVE_KID
+
+	mu     sync.RWMutex
+	keys   map[string]ed25519.PrivateKey
+	active string
+}
+
+// loadKeyring reads every key in dir. With no dir, it generates a throwaway
+// key so local development works without setup; tokens won't survive a restart.
+func loadKeyring(dir, activeKID string) (*keyring, error) {
+	k := &keyring{dir: dir, activeKID: activeKID}
+
+	if dir == "" {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+
+		kid := "dev-" + randSecret()[:8]
+		k.keys = map[string]ed25519.PrivateKey{kid: priv}
+		k.active = kid
+		log.Printf("JWT_KEYS_DIR not set; using ephemeral signing key %q", kid)
+
+		return k, nil
+	}
+
+	if err := k.reload(); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// reload re-reads the key directory. On error the previous keys stay in use.
+func (k *keyring) reload() error {
+	if k.dir == "" {
+		return nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(k.dir, "*.pem"))
+	if err != nil {
+		return err
+	}
+
+	keys := map[string]ed25519.PrivateKey{}
+	var kids []string
+	for _, p := range paths {
+		priv, err := readEd25519Key(p)
+		if err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+
+		kid := strings.TrimSuffix(filepath.Base(p), ".pem")
+		keys[kid] = priv
+		kids = append(kids, kid)
+	}
+
+	if len(kids) == 0 {
+		return fmt.Errorf("no signing keys in %s", k.dir)
+	}
+
+	// Name keys so they sort by age (e.g. 2026-10.pem) and the newest wins.
+	sort.Strings(kids)
+	active := kids[len(kids)-1]
+	if k.activeKID != "" {
+		if _, ok := keys[k.activeKID]; !ok {
+			return fmt.Errorf("JWT_ACTIVE_KID %q not found in %s", k.activeKID, k.dir)
+		}
+		active = k.activeKID
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if active != k.active {
+		log.Printf("signing with key %q (%d keys published)", active, len(keys))
+	}
+	k.keys = keys
+	k.active = active
+
+	return nil
+}
+
+// signer returns the key new tokens are signed with.
+func (k *keyring) signer() (string, ed25519.PrivateKey) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.active, k.keys[k.active]
+}
+
+// publicKey returns the verification key for kid, if it is still published.
+func (k *keyring) publicKey(kid string) (ed25519.PublicKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	priv, ok := k.keys[kid]
+	if !ok {
+		return nil, false
+	}
+
+	return priv.Public().(ed25519.PublicKey), true
+}
+
+// jwk is a single public key in RFC 8037 (OKP / Ed25519) form.
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+}
+
+// ServeHTTP serves the public keys as a JWKS document.
+func (k *keyring) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	k.mu.RLock()
+	set := struct {
+		Keys []jwk `json:"keys"`
+	}{Keys: []jwk{}}
+	for kid, priv := range k.keys {
+		set.Keys = append(set.Keys, jwk{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(priv.Public().(ed25519.PublicKey)),
+			Kid: kid,
+			Alg: "EdDSA",
+			Use: "sig",
+		})
+	}
+	k.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "max-age=300")
+	_ = json.NewEncoder(w).Encode(set)
+}
+
+// readEd25519Key parses a PKCS#8 PEM file, e.g. from
+// `openssl genpkey -algorithm ed25519 -out 2026-10.pem`.
+func readEd25519Key(path string) (ed25519.PrivateKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("no PEM block")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an Ed25519 key")
+	}
+
+	return priv, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RequestMagicLink emails a single-use login link to the account owner.
+// It returns success whether or not the email exists.
+func (s *server) RequestMagicLink(ctx context.Context, in *gv1.MagicLinkRequest) (*gv1.Empty, error) {
+	email := emailKey(in.Email)
+	if err := s.limitMail(ctx, purposeMagicLink, email); err != nil {
+		return nil, err
+	}
+
+	// Suspended accounts and those past their deletion date get nothing.
+	var uid int64
+	err := s.db.QueryRow(ctx, `
+SELECT id
+FROM users
+WHERE email = $1
+  AND suspended_at IS NULL
+  AND (delete_after IS NULL OR delete_after > now())`,
+		email,
+	).Scan(&uid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &gv1.Empty{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tok, err := issueOneTimeToken(ctx, s.db, uid, purposeMagicLink, s.magicLinkTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	link := s.baseURL + "/magic-login?token=" + url.QueryEscape(tok)
+	s.deliver(mail{
+		To:      email,
+		Subject: "Your GoDrive login link",
+		Body: fmt.Sprintf(
+			"Open this link to log in to GoDrive:\n%s\n\n"+
+				"The link expires in %s and works once. If you didn't ask for it, ignore this email.\n",
+			link, s.magicLinkTTL),
+	})
+	s.record(ctx, uid, "auth.magic_link_sent", userTarget(uid), nil)
+
+	return &gv1.Empty{}, nil
+}
+
+// CompleteMagicLinkLogin redeems a login link. It ends like a password
+// login: users with TOTP on still get an MFA challenge.
+func (s *server) CompleteMagicLinkLogin(ctx context.Context, in *gv1.MagicLinkLoginRequest) (*gv1.Token, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	uid, err := consumeOneTimeToken(ctx, tx, purposeMagicLink, in.Token)
+	if errors.Is(err, errBadOneTimeToken) {
+		return nil, grpcErr("invalid or expired login link")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Following the link proves the address, so it counts as verified.
+	ct, err := tx.Exec(ctx, `
+UPDATE users
+SET email_verified_at = COALESCE(email_verified_at, now())
+WHERE id = $1
+  AND (delete_after IS NULL OR delete_after > now())`, uid)
+	if err != nil {
+		return nil, err
+	}
+	if ct.RowsAffected() == 0 {
+		return nil, grpcErr("invalid or expired login link")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	log.Printf("magic link login: uid=%d", uid)
+
+	return s.completeLogin(ctx, uid, map[string]string{"method": "magic_link"})
+}
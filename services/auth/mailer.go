@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// mail is a plain-text email.
+type mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// mailer delivers mail. Implementations must be safe for concurrent use.
+type mailer interface {
+	Send(ctx context.Context, m mail) error
+}
+
+// newMailer picks a mailer from MAIL_DRIVER:
+//   - "smtp":   deliver through SMTP_ADDR (host:port), optionally with PLAIN auth
+//   - "outbox": write each message as a file under MAIL_OUTBOX_DIR (dev and tests)
+func newMailer() (mailer, error) {
+	from := env("MAIL_FROM", "GoDrive <no-reply@godrive.local>")
+
+	switch driver := env("MAIL_DRIVER", "outbox"); driver {
+	case "smtp":
+		addr := env("SMTP_ADDR", "localhost:25")
+
+		var auth smtp.Auth
+		if user := os.Getenv("SMTP_USERNAME"); user != "" {
+			host, _, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, fmt.Errorf("SMTP_ADDR: %w", err)
+			}
+			auth = smtp.PlainAuth("", user, os.Getenv("SMTP_PASSWORD"), host)
+		}
+
+		return &smtpMailer{addr: addr, from: from, auth: auth}, nil
+
+	case "outbox":
+		dir := env("MAIL_OUTBOX_DIR", "outbox")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+
+		return &outboxMailer{dir: dir, from: from}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown MAIL_DRIVER %q", driver)
+	}
+}
+
+// deliver sends msg in the background. Request handlers use it so that
+// response time doesn't depend on (or reveal) whether mail was sent.
+func (s *server) deliver(msg mail) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := s.mail.Send(ctx, msg); err != nil {
+			log.Printf("send mail %q to %s: %v", msg.Subject, msg.To, err)
+		}
+	}()
+}
+
+// Mail a client asks us to send (reset, verification and login links) is
+// limited per purpose: per address, whether or not it has an account, so the
+// limit can't be used to probe for accounts; and per client IP, so one client
+// can't flood many inboxes either.
+const (
+	mailPerAddress = 5         // mails of one purpose per address per window
+	mailPerIP      = 20        // mails of one purpose per client IP per window
+	mailWindow     = time.Hour // window the limits apply to
+)
+
+// limitMail counts a request to mail email for purpose against the address
+// and the client IP, refusing it once either has had its share within
+// mailWindow. email must already be normalised with emailKey.
+func (s *server) limitMail(ctx context.Context, purpose, email string) error {
+	keys := []throttleKey{{"email", email, mailPerAddress}}
+	if ip := clientIP(ctx); ip != "" {
+		keys = append(keys, throttleKey{"ip", ip, mailPerIP})
+	}
+
+	since := time.Now().Add(-mailWindow)
+	for _, k := range keys {
+		var (
+			n      int
+			oldest *time.Time
+		)
+		err := s.db.QueryRow(ctx, `
+SELECT count(*), min(requested_at)
+FROM mail_requests
+WHERE purpose = $1
+  AND kind = $2
+  AND value = $3
+  AND requested_at > $4`, purpose, k.kind, k.value, since,
+		).Scan(&n, &oldest)
+		if err != nil {
+			return err
+		}
+		if n >= k.lockAfter && oldest != nil {
+			return throttledErr("too many emails requested, try again later", time.Until(oldest.Add(mailWindow)))
+		}
+	}
+
+	for _, k := range keys {
+		_, err := s.db.Exec(ctx,
+			`INSERT INTO mail_requests(purpose, kind, value) VALUES($1, $2, $3)`,
+			purpose, k.kind, k.value,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// smtpMailer sends through an SMTP relay.
+type smtpMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg mail) error {
+	// net/smtp has no context support; honour cancellation before dialling at least.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return smtp.SendMail(m.addr, m.auth, envelopeAddr(m.from), []string{msg.To}, render(m.from, msg))
+}
+
+// outboxMailer writes each message to its own .eml file instead of sending
+// it. Tests and local dev read links straight out of the directory.
+type outboxMailer struct {
+	dir  string
+	from string
+}
+
+func (m *outboxMailer) Send(_ context.Context, msg mail) error {
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), strings.NewReplacer("@", "_at_", "/", "_").Replace(msg.To))
+
+	return os.WriteFile(filepath.Join(m.dir, name), render(m.from, msg), 0o644)
+}
+
+// render formats msg as an RFC 5322 message.
+func render(from string, msg mail) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	return []byte(b.String())
+}
+
+// envelopeAddr extracts the bare address from a "Name <addr>" header value.
+func envelopeAddr(from string) string {
+	if i := strings.LastIndex(from, "<"); i >= 0 {
+		return strings.TrimSuffix(from[i+1:], ">")
+	}
+
+	return from
+}
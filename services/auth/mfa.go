@@ -0,0 +1,352 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	mfaChallengeTTL   = 5 * time.Minute
+	mfaMaxAttempts    = 5  // wrong codes allowed per challenge before it is burned (see also mfaKey)
+	recoveryCodeCount = 10 // handed out on each TOTP confirmation
+)
+
+// completeLogin is where every successful first-factor login ends up,
+// password, magic link or SSO. Users with MFA get a short-lived challenge
+// token instead of a session. The login only counts, in the audit log and
+// against the throttle, once a session is issued; details describe the
+// first factor for the audit log.
+func (s *server) completeLogin(ctx context.Context, uid int64, details map[string]string) (*gv1.Token, error) {
+	var (
+		email          string
+		mfa, suspended bool
+	)
+	err := s.db.QueryRow(ctx,
+		`SELECT email, totp_enabled_at IS NOT NULL, suspended_at IS NOT NULL FROM users WHERE id = $1`,
+		uid,
+	).Scan(&email, &mfa, &suspended)
+	if err != nil {
+		return nil, err
+	}
+
+	// Don't hand a suspended account an MFA challenge it can't redeem.
+	if suspended {
+		return nil, errSuspended
+	}
+
+	if !mfa {
+		tok, err := s.startSession(ctx, s.db, uid)
+		if err != nil {
+			return nil, err
+		}
+		s.loggedIn(ctx, uid, email, details)
+		return tok, nil
+	}
+
+	challenge, err := issueOneTimeToken(ctx, s.db, uid, purposeMFALogin, mfaChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gv1.Token{MfaRequired: true, MfaToken: challenge}, nil
+}
+
+// CompleteMFALogin finishes a two-step login with a TOTP or recovery code.
+// Wrong codes count against the login throttle like wrong passwords do, and
+// against a per-user counter that new challenges don't reset.
+func (s *server) CompleteMFALogin(ctx context.Context, in *gv1.MFALoginRequest) (*gv1.Token, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		id, uid int64
+		email   string
+	)
+	err = tx.QueryRow(ctx, `
+SELECT t.id, t.user_id, u.email
+FROM one_time_tokens t
+JOIN users u ON u.id = t.user_id
+WHERE t.token_hash = $1
+  AND t.purpose = $2
+  AND t.used_at IS NULL
+  AND t.expires_at > now()
+FOR UPDATE OF t`,
+		hashToken(in.MfaToken), purposeMFALogin,
+	).Scan(&id, &uid, &email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, grpcErr("invalid or expired MFA challenge")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Refuse throttled attempts before trying the code.
+	keys := append(loginKeys(ctx, email), mfaKey(uid))
+	if err := s.checkThrottle(ctx, keys); err != nil {
+		return nil, err
+	}
+
+	ok, err := s.checkSecondFactor(ctx, tx, uid, in.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		// Count the miss and burn the challenge after too many, so the
+		// 6-digit space can't be walked within one challenge's lifetime.
+		_, err := tx.Exec(ctx, `
+UPDATE one_time_tokens
+SET attempts = attempts + 1,
+    used_at = CASE WHEN attempts + 1 >= $2 THEN now() END
+WHERE id = $1`, id, mfaMaxAttempts)
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+
+		s.recordLoginFailure(ctx, keys)
+		s.record(ctx, uid, "auth.mfa_failed", userTarget(uid), nil)
+		return nil, grpcErr("invalid code")
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE one_time_tokens SET used_at = now() WHERE id = $1`, id); err != nil {
+		return nil, err
+	}
+
+	tok, err := s.startSession(ctx, tx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	s.loggedIn(ctx, uid, email, map[string]string{"method": "mfa"})
+	s.clearThrottle(ctx, "mfa", strconv.FormatInt(uid, 10), "login")
+
+	return tok, nil
+}
+
+// loggedIn records a login that got a session and forgets the failed
+// attempts counted against the user's email.
+func (s *server) loggedIn(ctx context.Context, uid int64, email string, details map[string]string) {
+	s.record(ctx, uid, "auth.login", userTarget(uid), details)
+	s.clearLoginFailures(ctx, email, "login")
+}
+
+// checkSecondFactor accepts either a TOTP code (each time step at most once)
+// or an unused recovery code, which is then spent.
+func (s *server) checkSecondFactor(ctx context.Context, tx pgx.Tx, uid int64, code string) (bool, error) {
+	var (
+		secret   *string
+		lastStep *int64
+	)
+	err := tx.QueryRow(ctx,
+		`SELECT totp_secret, totp_last_step FROM users WHERE id = $1 FOR UPDATE`,
+		uid,
+	).Scan(&secret, &lastStep)
+	if err != nil {
+		return false, err
+	}
+
+	if secret != nil {
+		if step, ok := checkTOTP(*secret, code, time.Now()); ok && (lastStep == nil || step > *lastStep) {
+			_, err := tx.Exec(ctx, `UPDATE users SET totp_last_step = $1 WHERE id = $2`, step, uid)
+			return err == nil, err
+		}
+	}
+
+	ct, err := tx.Exec(ctx, `
+UPDATE mfa_recovery_codes
+SET used_at = now()
+WHERE user_id = $1
+  AND code_hash = $2
+  AND used_at IS NULL`, uid, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return false, err
+	}
+
+	if ct.RowsAffected() == 1 {
+		log.Printf("recovery code used: uid=%d", uid)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// EnrollTOTP generates a new TOTP secret for the caller. MFA isn't enforced
+// until ConfirmTOTP proves the authenticator app has it.
+func (s *server) EnrollTOTP(ctx context.Context, _ *gv1.Empty) (*gv1.TOTPEnrollment, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		email   string
+		enabled bool
+	)
+	err = s.db.QueryRow(ctx,
+		`SELECT email, totp_enabled_at IS NOT NULL FROM users WHERE id = $1`,
+		c.UID,
+	).Scan(&email, &enabled)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, status.Error(codes.FailedPrecondition, "TOTP already enabled")
+	}
+
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.db.Exec(ctx, `UPDATE users SET totp_secret = $1 WHERE id = $2`, secret, c.UID); err != nil {
+		return nil, err
+	}
+
+	return &gv1.TOTPEnrollment{
+		Secret:     secret,
+		OtpauthUrl: totpURL("GoDrive", email, secret),
+	}, nil
+}
+
+// ConfirmTOTP turns MFA on once the caller proves their app produces valid
+// codes, and returns a fresh set of one-time recovery codes (shown only once).
+func (s *server) ConfirmTOTP(ctx context.Context, in *gv1.TOTPCode) (*gv1.RecoveryCodes, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		secret  *string
+		enabled bool
+	)
+	err = tx.QueryRow(ctx,
+		`SELECT totp_secret, totp_enabled_at IS NOT NULL FROM users WHERE id = $1 FOR UPDATE`,
+		c.UID,
+	).Scan(&secret, &enabled)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, status.Error(codes.FailedPrecondition, "TOTP already enabled")
+	}
+	if secret == nil {
+		return nil, status.Error(codes.FailedPrecondition, "call EnrollTOTP first")
+	}
+
+	step, ok := checkTOTP(*secret, in.Code, time.Now())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid code")
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE users SET totp_enabled_at = now(), totp_last_step = $1 WHERE id = $2`,
+		step, c.UID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	recovery, err := newRecoveryCodes(ctx, tx, c.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	log.Printf("TOTP enabled: uid=%d", c.UID)
+	s.record(ctx, c.UID, "auth.mfa_enabled", userTarget(c.UID), nil)
+
+	return &gv1.RecoveryCodes{Codes: recovery}, nil
+}
+
+// AdminResetMFA switches MFA off for a user who lost their device and their
+// recovery codes. They can log in with just a password and enroll again.
+// Admin only (see rolePolicy).
+func (s *server) AdminResetMFA(ctx context.Context, in *gv1.ResetMFARequest) (*gv1.Empty, error) {
+	ct, err := s.db.Exec(ctx, `
+UPDATE users
+SET totp_secret = NULL,
+    totp_enabled_at = NULL,
+    totp_last_step = NULL
+WHERE id = $1`, in.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if ct.RowsAffected() == 0 {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	if _, err := s.db.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, in.UserId); err != nil {
+		return nil, err
+	}
+
+	log.Printf("MFA reset by admin: uid=%d", in.UserId)
+	s.record(ctx, s.actor(ctx), "auth.mfa_reset", userTarget(in.UserId), nil)
+
+	return &gv1.Empty{}, nil
+}
+
+// newRecoveryCodes replaces uid's recovery codes and returns the new ones in plaintext.
+func newRecoveryCodes(ctx context.Context, tx pgx.Tx, uid int64) ([]string, error) {
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, uid); err != nil {
+		return nil, err
+	}
+
+	out := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		// 80 random bits, shown as xxxx-xxxx-xxxx-xxxx.
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		raw := strings.ToLower(b32.EncodeToString(b))
+		code := raw[0:4] + "-" + raw[4:8] + "-" + raw[8:12] + "-" + raw[12:16]
+
+		_, err := tx.Exec(ctx,
+			`INSERT INTO mfa_recovery_codes(user_id, code_hash) VALUES($1, $2)`,
+			uid, hashToken(normalizeRecoveryCode(code)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, code)
+	}
+
+	return out, nil
+}
+
+// normalizeRecoveryCode makes recovery codes forgiving to type: case,
+// dashes and spaces don't matter.
+func normalizeRecoveryCode(code string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+}
@@ -0,0 +1,486 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// oidcLoginTTL bounds how long a user may spend at the identity provider.
+const oidcLoginTTL = 10 * time.Minute
+
+// oidcProvider is a single OpenID Connect identity provider we accept logins from.
+// Endpoints are discovered lazily so auth can start before the IdP is up.
+type oidcProvider struct {
+	issuer       string
+	clientID     string
+	clientSecret string // empty for public clients; PKCE protects the code either way
+	redirectURL  string // the gateway's /auth/oidc/callback
+	scopes       string
+	client       *http.Client
+
+	mu       sync.Mutex
+	authURL  string
+	tokenURL string
+	jwksURL  string
+	keys     map[string]interface{} // kid -> *rsa.PublicKey | *ecdsa.PublicKey
+}
+
+// newOIDCProvider returns nil when OIDC_ISSUER is unset, which disables federated login.
+func newOIDCProvider() *oidcProvider {
+	issuer := strings.TrimSuffix(env("OIDC_ISSUER", ""), "/")
+	if issuer == "" {
+		return nil
+	}
+
+	return &oidcProvider{
+		issuer:       issuer,
+		clientID:     env("OIDC_CLIENT_ID", "godrive"),
+		clientSecret: env("OIDC_CLIENT_SECRET", ""),
+		redirectURL:  env("OIDC_REDIRECT_URL", "http://localhost:8080/auth/oidc/callback"),
+		scopes:       env("OIDC_SCOPES", "openid email profile"),
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// discover loads the provider's endpoints from its discovery document, once.
+func (p *oidcProvider) discover(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.tokenURL != "" {
+		return nil
+	}
+
+	var doc struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		JWKSURI               string `json:"jwks_uri"`
+	}
+	if err := p.getJSON(ctx, p.issuer+"/.well-known/openid-configuration", &doc); err != nil {
+		return fmt.Errorf("OIDC discovery: %w", err)
+	}
+	if strings.TrimSuffix(doc.Issuer, "/") != p.issuer {
+		return fmt.Errorf("OIDC discovery: issuer %q does not match %q", doc.Issuer, p.issuer)
+	}
+
+	p.authURL = doc.AuthorizationEndpoint
+	p.tokenURL = doc.TokenEndpoint
+	p.jwksURL = doc.JWKSURI
+
+	return nil
+}
+
+// BeginOIDCLogin starts an authorization-code + PKCE login. The gateway
+// redirects the browser to the returned URL and binds the state to a cookie.
+func (s *server) BeginOIDCLogin(ctx context.Context, _ *gv1.Empty) (*gv1.OIDCAuthURL, error) {
+	p := s.oidc
+	if p == nil {
+		return nil, status.Error(codes.FailedPrecondition, "OIDC login not configured")
+	}
+	if err := p.discover(ctx); err != nil {
+		log.Printf("begin OIDC login: %v", err)
+		return nil, status.Error(codes.Unavailable, "identity provider unavailable")
+	}
+
+	state, err := randToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randToken()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randToken()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.db.Exec(ctx, `
+INSERT INTO oidc_logins(state_hash, nonce, code_verifier, expires_at)
+VALUES($1, $2, $3, $4)`,
+		hashToken(state), nonce, verifier, time.Now().Add(oidcLoginTTL),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.clientID)
+	q.Set("redirect_uri", p.redirectURL)
+	q.Set("scope", p.scopes)
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	q.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(p.authURL, "?") {
+		sep = "&"
+	}
+
+	return &gv1.OIDCAuthURL{Url: p.authURL + sep + q.Encode(), State: state}, nil
+}
+
+// CompleteOIDCLogin redeems the authorization code from the IdP callback,
+// validates the ID token, links the identity to a user and issues our tokens.
+func (s *server) CompleteOIDCLogin(ctx context.Context, in *gv1.OIDCCallback) (*gv1.Token, error) {
+	p := s.oidc
+	if p == nil {
+		return nil, status.Error(codes.FailedPrecondition, "OIDC login not configured")
+	}
+
+	var nonce, verifier string
+	err := s.db.QueryRow(ctx, `
+UPDATE oidc_logins
+SET used_at = now()
+WHERE state_hash = $1
+  AND used_at IS NULL
+  AND expires_at > now()
+RETURNING nonce, code_verifier`,
+		hashToken(in.State),
+	).Scan(&nonce, &verifier)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, grpcErr("invalid or expired login state")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := p.exchange(ctx, in.Code, verifier, nonce)
+	if err != nil {
+		log.Printf("OIDC code exchange: %v", err)
+		return nil, grpcErr("identity provider login failed")
+	}
+
+	uid, err := s.linkIdentity(ctx, p.issuer, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The IdP's own second factor is no proof of ours: an account with TOTP
+	// on, perhaps linked just now by email, still has to pass the challenge.
+	return s.completeLogin(ctx, uid, map[string]string{"method": "oidc", "issuer": p.issuer})
+}
+
+// idTokenClaims is what we read from the IdP's ID token.
+type idTokenClaims struct {
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	jwt.RegisteredClaims
+}
+
+// exchange trades the authorization code for tokens and returns the
+// validated ID token claims.
+func (p *oidcProvider) exchange(ctx context.Context, code, verifier, nonce string) (*idTokenClaims, error) {
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.redirectURL)
+	form.Set("client_id", p.clientID)
+	form.Set("code_verifier", verifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if p.clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.clientID), url.QueryEscape(p.clientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint status %d", resp.StatusCode)
+	}
+
+	var out struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	if out.IDToken == "" {
+		return nil, errors.New("no id_token in response")
+	}
+
+	claims := &idTokenClaims{}
+	_, err = jwt.ParseWithClaims(out.IDToken, claims, p.keyfunc(ctx),
+		jwt.WithValidMethods([]string{"RS256", "ES256"}),
+		jwt.WithIssuer(p.issuer),
+		jwt.WithAudience(p.clientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Nonce != nonce {
+		return nil, errors.New("nonce mismatch")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("no sub in id_token")
+	}
+
+	return claims, nil
+}
+
+// linkIdentity maps an external identity to a users row, in this order:
+// an existing link; an existing account with the same (IdP-verified) email;
+// otherwise a new passwordless account.
+func (s *server) linkIdentity(ctx context.Context, provider string, id *idTokenClaims) (int64, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	uid, reclaimed, err := s.link(ctx, tx, provider, id)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	if reclaimed != nil {
+		s.publishRevocation(*reclaimed)
+		log.Printf("reclaimed unverified account on OIDC login: uid=%d provider=%s", uid, provider)
+		s.record(ctx, uid, "auth.account_reclaimed", userTarget(uid), map[string]string{"provider": provider})
+	}
+
+	return uid, nil
+}
+
+// link is linkIdentity within q. If it links an account whose email was
+// never verified, it also takes the account back from whoever made it (see
+// reclaimAccount) and returns the revocation to publish once q commits.
+func (s *server) link(ctx context.Context, q dbtx, provider string, id *idTokenClaims) (int64, *revocation, error) {
+	var uid int64
+	err := q.QueryRow(ctx, `
+UPDATE identities
+SET last_login_at = now(), email = $3
+WHERE provider = $1
+  AND subject = $2
+RETURNING user_id`,
+		provider, id.Subject, id.Email,
+	).Scan(&uid)
+	if err == nil {
+		return uid, nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil, err
+	}
+
+	// Only trust the email for linking if the IdP vouches for it; otherwise
+	// anyone could claim an existing user's address at their IdP.
+	if id.Email == "" || !id.EmailVerified {
+		return 0, nil, status.Error(codes.PermissionDenied, "identity provider did not supply a verified email")
+	}
+
+	var (
+		verified  bool
+		reclaimed *revocation
+	)
+	err = q.QueryRow(ctx,
+		`SELECT id, email_verified_at IS NOT NULL FROM users WHERE email = lower($1)`,
+		id.Email,
+	).Scan(&uid, &verified)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// New accounts are subject to the signup mode; SSO has no invite codes.
+		if _, err := s.signup.admit(ctx, q, strings.ToLower(id.Email), ""); err != nil {
+			return 0, nil, err
+		}
+
+		// Federated-only accounts have no password; password login can never match.
+		err = q.QueryRow(ctx, `
+INSERT INTO users(email, password_hash, email_verified_at)
+VALUES(lower($1), '', now())
+RETURNING id`, id.Email).Scan(&uid)
+		if err != nil {
+			return 0, nil, err
+		}
+		log.Printf("created user from OIDC identity: uid=%d", uid)
+	} else if err != nil {
+		return 0, nil, err
+	} else if !verified {
+		ev, err := s.reclaimAccount(ctx, q, uid)
+		if err != nil {
+			return 0, nil, err
+		}
+		reclaimed = &ev
+	}
+
+	_, err = q.Exec(ctx, `
+INSERT INTO identities(user_id, provider, subject, email, last_login_at)
+VALUES($1, $2, $3, $4, now())`,
+		uid, provider, id.Subject, id.Email,
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// The IdP verified this address, so we can too.
+	_, err = q.Exec(ctx,
+		`UPDATE users SET email_verified_at = COALESCE(email_verified_at, now()) WHERE id = $1`,
+		uid,
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	log.Printf("linked OIDC identity: uid=%d provider=%s", uid, provider)
+
+	return uid, reclaimed, nil
+}
+
+// reclaimAccount hands an account that never verified its email over to
+// the address's owner, whom the IdP just vouched for. Whoever signed up with
+// it may not have been them, so the password and second factor they set
+// stop working, and so does every token, session and personal access token
+// issued so far.
+func (s *server) reclaimAccount(ctx context.Context, q dbtx, uid int64) (revocation, error) {
+	_, err := q.Exec(ctx, `
+UPDATE users
+SET password_hash = '', totp_secret = NULL, totp_enabled_at = NULL, totp_last_step = NULL
+WHERE id = $1`, uid)
+	if err != nil {
+		return revocation{}, err
+	}
+	if _, err := q.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, uid); err != nil {
+		return revocation{}, err
+	}
+
+	ev, err := s.revokeUserTokens(ctx, q, uid)
+	if err != nil {
+		return revocation{}, err
+	}
+	_, err = q.Exec(ctx,
+		`UPDATE personal_access_tokens SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`,
+		uid,
+	)
+
+	return ev, err
+}
+
+// keyfunc resolves ID token signing keys from the IdP's JWKS, refetching on an unknown kid.
+func (p *oidcProvider) keyfunc(ctx context.Context) jwt.Keyfunc {
+	return func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+
+		p.mu.Lock()
+		key, ok := p.keys[kid]
+		p.mu.Unlock()
+		if ok {
+			return key, nil
+		}
+
+		if err := p.fetchKeys(ctx); err != nil {
+			return nil, err
+		}
+
+		p.mu.Lock()
+		key, ok = p.keys[kid]
+		p.mu.Unlock()
+		if !ok {
+			return nil, fmt.Errorf("unknown kid %q", kid)
+		}
+
+		return key, nil
+	}
+}
+
+func (p *oidcProvider) fetchKeys(ctx context.Context) error {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	if err := p.getJSON(ctx, p.jwksURL, &set); err != nil {
+		return err
+	}
+
+	keys := map[string]interface{}{}
+	for _, k := range set.Keys {
+		switch {
+		case k.Kty == "RSA":
+			n, err1 := base64.RawURLEncoding.DecodeString(k.N)
+			e, err2 := base64.RawURLEncoding.DecodeString(k.E)
+			if err1 != nil || err2 != nil {
+				continue
+			}
+			keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+
+		case k.Kty == "EC" && k.Crv == "P-256":
+			x, err1 := base64.RawURLEncoding.DecodeString(k.X)
+			y, err2 := base64.RawURLEncoding.DecodeString(k.Y)
+			if err1 != nil || err2 != nil {
+				continue
+			}
+			keys[k.Kid] = &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		}
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+
+	return nil
+}
+
+func (p *oidcProvider) getJSON(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", u, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// account is a users row in fakeAccounts.
+type account struct {
+	id       int64
+	email    string
+	verified bool
+	password string
+	totp     bool
+	revoked  bool // tokens_valid_after moved, sessions and PATs revoked
+	linked   bool // has an identities row
+}
+
+// fakeAccounts stands in for the database in link: it knows the statements
+// link and reclaimAccount run, by their first words, and keeps no links.
+type fakeAccounts struct {
+	users []*account
+	pats  map[int64]bool // user ID -> PATs revoked
+}
+
+func (f *fakeAccounts) find(match func(*account) bool) *account {
+	for _, a := range f.users {
+		if match(a) {
+			return a
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeAccounts) byID(args []any) *account {
+	return f.find(func(a *account) bool { return a.id == args[0].(int64) })
+}
+
+func (f *fakeAccounts) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	sql = strings.Join(strings.Fields(sql), " ")
+	switch {
+	case strings.HasPrefix(sql, "INSERT INTO identities"):
+		f.byID(args).linked = true
+	case strings.HasPrefix(sql, "UPDATE users SET email_verified_at"):
+		f.byID(args).verified = true
+	case strings.HasPrefix(sql, "UPDATE users SET password_hash = ''"):
+		a := f.byID(args)
+		a.password, a.totp = "", false
+	case strings.HasPrefix(sql, "UPDATE personal_access_tokens"):
+		f.pats[args[0].(int64)] = true
+	case strings.HasPrefix(sql, "UPDATE refresh_tokens"),
+		strings.HasPrefix(sql, "UPDATE sessions"),
+		strings.HasPrefix(sql, "DELETE FROM mfa_recovery_codes"):
+	default:
+		return pgconn.CommandTag{}, errors.New("unexpected Exec: " + sql)
+	}
+
+	return pgconn.CommandTag{}, nil
+}
+
+func (f *fakeAccounts) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, errors.New("unexpected Query")
+}
+
+func (f *fakeAccounts) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	sql = strings.Join(strings.Fields(sql), " ")
+	switch {
+	case strings.HasPrefix(sql, "UPDATE identities"):
+		return row{err: pgx.ErrNoRows}
+	case strings.HasPrefix(sql, "SELECT id, email_verified_at IS NOT NULL FROM users"):
+		a := f.find(func(a *account) bool { return a.email == strings.ToLower(args[0].(string)) })
+		if a == nil {
+			return row{err: pgx.ErrNoRows}
+		}
+		return row{vals: []any{a.id, a.verified}}
+	case strings.HasPrefix(sql, "INSERT INTO users"):
+		a := &account{id: int64(len(f.users) + 1), email: strings.ToLower(args[0].(string)), verified: true}
+		f.users = append(f.users, a)
+		return row{vals: []any{a.id}}
+	case strings.HasPrefix(sql, "UPDATE users SET tokens_valid_after"):
+		f.byID(args).revoked = true
+		return row{vals: []any{time.Now()}}
+	}
+
+	return row{err: errors.New("unexpected QueryRow: " + sql)}
+}
+
+// row is a QueryRow result of the values given, in order.
+type row struct {
+	vals []any
+	err  error
+}
+
+func (r row) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	for i, d := range dest {
+		switch d := d.(type) {
+		case *int64:
+			*d = r.vals[i].(int64)
+		case *bool:
+			*d = r.vals[i].(bool)
+		case *time.Time:
+			*d = r.vals[i].(time.Time)
+		default:
+			return errors.New("unexpected Scan destination")
+		}
+	}
+
+	return nil
+}
+
+func TestLinkByEmail(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      *account
+		emailVerified bool // by the IdP
+		wantErr       bool
+		wantReclaim   bool
+	}{
+		{
+			name:          "new account",
+			emailVerified: true,
+		},
+		{
+			name:          "verified account",
+			existing:      &account{id: 1, email: "a@example.com", verified: true, password: "hash", totp: true},
+			emailVerified: true,
+		},
+		{
+			// Someone signed up with the address before its owner came
+			// through SSO: they must not keep a way in.
+			name:          "unverified account",
+			existing:      &account{id: 1, email: "a@example.com", password: "attacker's", totp: true},
+			emailVerified: true,
+			wantReclaim:   true,
+		},
+		{
+			name:          "email not verified by the IdP",
+			existing:      &account{id: 1, email: "a@example.com", verified: true, password: "hash"},
+			emailVerified: false,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeAccounts{pats: map[int64]bool{}}
+			if tt.existing != nil {
+				f.users = append(f.users, tt.existing)
+			}
+			before := account{}
+			if tt.existing != nil {
+				before = *tt.existing
+			}
+
+			s := &server{accessTTL: time.Minute}
+			id := &idTokenClaims{Email: "A@Example.com", EmailVerified: tt.emailVerified}
+			id.Subject = "sub-1"
+
+			uid, reclaimed, err := s.link(context.Background(), f, "https://idp.example", id)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("link = %d, want an error", uid)
+				}
+				if tt.existing.linked {
+					t.Error("linked an identity the IdP didn't verify the email of")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("link error = %v", err)
+			}
+
+			a := f.byID([]any{uid})
+			if a == nil || a.email != "a@example.com" {
+				t.Fatalf("link = %d, not the account for a@example.com", uid)
+			}
+			if !a.linked || !a.verified {
+				t.Errorf("account linked = %v, verified = %v; want both", a.linked, a.verified)
+			}
+			if (reclaimed != nil) != tt.wantReclaim {
+				t.Fatalf("reclaimed = %v, want %v", reclaimed != nil, tt.wantReclaim)
+			}
+
+			if tt.wantReclaim {
+				if reclaimed.UserID != uid {
+					t.Errorf("revocation for user %d, want %d", reclaimed.UserID, uid)
+				}
+				if a.password != "" || a.totp {
+					t.Errorf("password = %q, totp = %v; want both cleared", a.password, a.totp)
+				}
+				if !a.revoked || !f.pats[uid] {
+					t.Errorf("tokens revoked = %v, PATs revoked = %v; want both", a.revoked, f.pats[uid])
+				}
+				return
+			}
+			if a.password != before.password || a.totp != before.totp || a.revoked || f.pats[uid] {
+				t.Errorf("account changed: %+v, was %+v", *a, before)
+			}
+		})
+	}
+}
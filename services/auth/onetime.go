@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Purposes for one_time_tokens. A token only redeems for the purpose it was issued for.
+const (
+	purposePasswordReset = "password_reset"
+	purposeVerifyEmail   = "verify_email"
+	purposeMFALogin      = "mfa_login" // second step of a login with TOTP on
+	purposeMagicLink     = "magic_link"
+)
+
+// errBadOneTimeToken is returned for unknown, used, expired or wrong-purpose tokens.
+// Callers map it to a single user-facing error so none of those cases can be told apart.
+var errBadOneTimeToken = errors.New("invalid or expired token")
+
+// issueOneTimeToken creates a single-use token for uid and returns it in
+// plaintext (only the hash is stored). Any outstanding token for the same
+// purpose is invalidated, so only the most recent email link works.
+func issueOneTimeToken(ctx context.Context, q dbtx, uid int64, purpose string, ttl time.Duration) (string, error) {
+	tok, err := randToken()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = q.Exec(ctx, `
+UPDATE one_time_tokens
+SET used_at = now()
+WHERE user_id = $1
+  AND purpose = $2
+  AND used_at IS NULL`, uid, purpose)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = q.Exec(ctx, `
+INSERT INTO one_time_tokens(user_id, purpose, token_hash, expires_at)
+VALUES($1, $2, $3, $4)`,
+		uid, purpose, hashToken(tok), time.Now().Add(ttl),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return tok, nil
+}
+
+// consumeOneTimeToken redeems tok for purpose and returns its user. Run it
+// inside the transaction that acts on the token so a failure leaves it unused.
+func consumeOneTimeToken(ctx context.Context, tx pgx.Tx, purpose, tok string) (int64, error) {
+	var uid int64
+	err := tx.QueryRow(ctx, `
+UPDATE one_time_tokens
+SET used_at = now()
+WHERE token_hash = $1
+  AND purpose = $2
+  AND used_at IS NULL
+  AND expires_at > now()
+RETURNING user_id`,
+		hashToken(tok), purpose,
+	).Scan(&uid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, errBadOneTimeToken
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return uid, nil
+}
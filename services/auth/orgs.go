@@ -0,0 +1,584 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Roles within an organization. They are independent of the global role in
+// users.role (see internal/rbac).
+const (
+	orgOwner  = "owner"
+	orgAdmin  = "admin"
+	orgMember = "member"
+)
+
+const (
+	invitationTTL    = 7 * 24 * time.Hour
+	maxOrgNameLen    = 100
+	maxPendingInvite = 100 // per org, so invitations can't be used to spam
+)
+
+// orgRank orders org roles so "at least admin" checks are a comparison.
+var orgRank = map[string]int{orgMember: 1, orgAdmin: 2, orgOwner: 3}
+
+// orgServer implements gv1.OrgServiceServer on top of the auth server's
+// database, mailer and token handling.
+type orgServer struct {
+	gv1.UnimplementedOrgServiceServer
+	*server
+}
+
+// memberRole returns uid's role in org, or NotFound if they aren't a member.
+// Non-members can't tell an org they don't belong to from one that doesn't exist.
+func memberRole(ctx context.Context, q dbtx, org, uid int64) (string, error) {
+	var role string
+	err := q.QueryRow(ctx,
+		`SELECT role FROM org_members WHERE org_id = $1 AND user_id = $2`,
+		org, uid,
+	).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", status.Error(codes.NotFound, "organization not found")
+	}
+
+	return role, err
+}
+
+// requireOrgRole is memberRole plus a minimum role.
+func requireOrgRole(ctx context.Context, q dbtx, org, uid int64, atLeast string) (string, error) {
+	role, err := memberRole(ctx, q, org, uid)
+	if err != nil {
+		return "", err
+	}
+	if orgRank[role] < orgRank[atLeast] {
+		return "", status.Errorf(codes.PermissionDenied, "requires org role %s", atLeast)
+	}
+
+	return role, nil
+}
+
+// CreateOrg creates an organization with the caller as its owner.
+func (s *orgServer) CreateOrg(ctx context.Context, in *gv1.CreateOrgRequest) (*gv1.Org, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(in.Name)
+	if name == "" || len(name) > maxOrgNameLen {
+		return nil, status.Errorf(codes.InvalidArgument, "name must be 1-%d characters", maxOrgNameLen)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	org := &gv1.Org{Name: name, Role: orgOwner}
+	var created time.Time
+	err = tx.QueryRow(ctx,
+		`INSERT INTO orgs(name, created_by) VALUES($1, $2) RETURNING id, created_at`,
+		name, c.UID,
+	).Scan(&org.Id, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO org_members(org_id, user_id, role) VALUES($1, $2, $3)`,
+		org.Id, c.UID, orgOwner,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	org.CreatedAt = created.UTC().Format(time.RFC3339)
+	log.Printf("org created: id=%d owner=%d", org.Id, c.UID)
+
+	return org, nil
+}
+
+// ListOrgs lists the organizations the caller belongs to.
+func (s *orgServer) ListOrgs(ctx context.Context, _ *gv1.Empty) (*gv1.OrgList, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `
+SELECT o.id, o.name, m.role, o.created_at
+FROM org_members m
+JOIN orgs o ON o.id = m.org_id
+WHERE m.user_id = $1
+ORDER BY o.name`, c.UID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := &gv1.OrgList{}
+	for rows.Next() {
+		var (
+			o       gv1.Org
+			created time.Time
+		)
+		if err := rows.Scan(&o.Id, &o.Name, &o.Role, &created); err != nil {
+			return nil, err
+		}
+		o.CreatedAt = created.UTC().Format(time.RFC3339)
+		out.Orgs = append(out.Orgs, &o)
+	}
+
+	return out, rows.Err()
+}
+
+// ListMembers lists an organization's members. Any member may see them.
+func (s *orgServer) ListMembers(ctx context.Context, in *gv1.OrgRequest) (*gv1.OrgMemberList, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := memberRole(ctx, s.db, in.OrgId, c.UID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `
+SELECT u.id, u.email, m.role, m.joined_at
+FROM org_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.org_id = $1
+ORDER BY m.joined_at`, in.OrgId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := &gv1.OrgMemberList{}
+	for rows.Next() {
+		var (
+			m      gv1.OrgMember
+			joined time.Time
+		)
+		if err := rows.Scan(&m.UserId, &m.Email, &m.Role, &joined); err != nil {
+			return nil, err
+		}
+		m.JoinedAt = joined.UTC().Format(time.RFC3339)
+		out.Members = append(out.Members, &m)
+	}
+
+	return out, rows.Err()
+}
+
+// InviteMember invites an email address to the organization and mails the
+// invitee. Admins may invite members and admins; only owners may invite owners.
+func (s *orgServer) InviteMember(ctx context.Context, in *gv1.InviteMemberRequest) (*gv1.OrgInvitation, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	role := in.Role
+	if role == "" {
+		role = orgMember
+	}
+	if _, ok := orgRank[role]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown org role %q", in.Role)
+	}
+	email, err := normalizeEmail(in.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	myRole, err := requireOrgRole(ctx, s.db, in.OrgId, c.UID, orgAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if orgRank[role] > orgRank[myRole] {
+		return nil, status.Error(codes.PermissionDenied, "cannot invite with a higher role than your own")
+	}
+
+	var isMember bool
+	err = s.db.QueryRow(ctx, `
+SELECT EXISTS(
+  SELECT 1 FROM org_members m JOIN users u ON u.id = m.user_id
+  WHERE m.org_id = $1 AND u.email = $2)`, in.OrgId, email).Scan(&isMember)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		return nil, status.Error(codes.AlreadyExists, "already a member")
+	}
+
+	var pending int
+	err = s.db.QueryRow(ctx, `
+SELECT count(*) FROM org_invitations
+WHERE org_id = $1 AND accepted_at IS NULL AND declined_at IS NULL AND expires_at > now()`,
+		in.OrgId,
+	).Scan(&pending)
+	if err != nil {
+		return nil, err
+	}
+	if pending >= maxPendingInvite {
+		return nil, status.Errorf(codes.ResourceExhausted, "at most %d pending invitations", maxPendingInvite)
+	}
+
+	inv := &gv1.OrgInvitation{OrgId: in.OrgId, Email: email, Role: role}
+	var expires time.Time
+	// A new invitation for the same address replaces the pending one.
+	err = s.db.QueryRow(ctx, `
+WITH gone AS (
+  DELETE FROM org_invitations
+  WHERE org_id = $1 AND email = $2 AND accepted_at IS NULL AND declined_at IS NULL
+)
+INSERT INTO org_invitations(org_id, email, role, invited_by, expires_at)
+VALUES($1, $2, $3, $4, $5)
+RETURNING id, expires_at, (SELECT name FROM orgs WHERE id = $1), (SELECT email FROM users WHERE id = $4)`,
+		in.OrgId, email, role, c.UID, time.Now().Add(invitationTTL),
+	).Scan(&inv.Id, &expires, &inv.OrgName, &inv.InvitedBy)
+	if err != nil {
+		return nil, err
+	}
+	inv.ExpiresAt = expires.UTC().Format(time.RFC3339)
+
+	log.Printf("org invitation: org=%d id=%d role=%s by=%d", in.OrgId, inv.Id, role, c.UID)
+
+	s.deliver(mail{
+		To:      email,
+		Subject: fmt.Sprintf("You're invited to %s on GoDrive", inv.OrgName),
+		Body: fmt.Sprintf(
+			"%s invited you to join %s on GoDrive as %s.\n\n"+
+				"Log in (or sign up) with this email address to accept or decline:\n%s/invitations\n\n"+
+				"The invitation expires in %s.\n",
+			inv.InvitedBy, inv.OrgName, role, s.baseURL, invitationTTL),
+	})
+
+	return inv, nil
+}
+
+// ListInvitations lists pending invitations addressed to the caller's email.
+func (s *orgServer) ListInvitations(ctx context.Context, _ *gv1.Empty) (*gv1.OrgInvitationList, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `
+SELECT i.id, i.org_id, o.name, i.email, i.role, inviter.email, i.expires_at
+FROM org_invitations i
+JOIN orgs o ON o.id = i.org_id
+JOIN users me ON me.id = $1 AND me.email = i.email
+LEFT JOIN users inviter ON inviter.id = i.invited_by
+WHERE i.accepted_at IS NULL
+  AND i.declined_at IS NULL
+  AND i.expires_at > now()
+ORDER BY i.created_at DESC`, c.UID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := &gv1.OrgInvitationList{}
+	for rows.Next() {
+		var (
+			inv     gv1.OrgInvitation
+			inviter *string
+			expires time.Time
+		)
+		if err := rows.Scan(&inv.Id, &inv.OrgId, &inv.OrgName, &inv.Email, &inv.Role, &inviter, &expires); err != nil {
+			return nil, err
+		}
+		if inviter != nil {
+			inv.InvitedBy = *inviter
+		}
+		inv.ExpiresAt = expires.UTC().Format(time.RFC3339)
+		out.Invitations = append(out.Invitations, &inv)
+	}
+
+	return out, rows.Err()
+}
+
+// pendingInvitation locks the caller's pending invitation id. The caller's
+// email must be verified, or anyone could sign up as the invitee and accept.
+func (s *orgServer) pendingInvitation(ctx context.Context, tx pgx.Tx, id, uid int64) (org int64, role string, err error) {
+	var verified bool
+	err = tx.QueryRow(ctx, `
+SELECT i.org_id, i.role, u.email_verified_at IS NOT NULL
+FROM org_invitations i
+JOIN users u ON u.id = $2 AND u.email = i.email
+WHERE i.id = $1
+  AND i.accepted_at IS NULL
+  AND i.declined_at IS NULL
+  AND i.expires_at > now()
+FOR UPDATE OF i`, id, uid).Scan(&org, &role, &verified)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, "", status.Error(codes.NotFound, "invitation not found")
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	if !verified {
+		return 0, "", status.Error(codes.FailedPrecondition, "verify your email address first")
+	}
+
+	return org, role, nil
+}
+
+// AcceptInvitation makes the caller a member with the invited role.
+func (s *orgServer) AcceptInvitation(ctx context.Context, in *gv1.InvitationRequest) (*gv1.Org, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	org, role, err := s.pendingInvitation(ctx, tx, in.Id, c.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE org_invitations SET accepted_at = now() WHERE id = $1`, in.Id); err != nil {
+		return nil, err
+	}
+
+	// Someone who is already a member keeps their current role.
+	_, err = tx.Exec(ctx, `
+INSERT INTO org_members(org_id, user_id, role) VALUES($1, $2, $3)
+ON CONFLICT (org_id, user_id) DO NOTHING`, org, c.UID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &gv1.Org{Id: org}
+	var created time.Time
+	err = tx.QueryRow(ctx, `
+SELECT o.name, m.role, o.created_at
+FROM orgs o JOIN org_members m ON m.org_id = o.id AND m.user_id = $2
+WHERE o.id = $1`, org, c.UID).Scan(&out.Name, &out.Role, &created)
+	if err != nil {
+		return nil, err
+	}
+	out.CreatedAt = created.UTC().Format(time.RFC3339)
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	log.Printf("org invitation accepted: org=%d uid=%d", org, c.UID)
+
+	return out, nil
+}
+
+// DeclineInvitation turns an invitation down.
+func (s *orgServer) DeclineInvitation(ctx context.Context, in *gv1.InvitationRequest) (*gv1.Empty, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, _, err := s.pendingInvitation(ctx, tx, in.Id, c.UID); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE org_invitations SET declined_at = now() WHERE id = $1`, in.Id); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &gv1.Empty{}, nil
+}
+
+// RemoveMember removes a member. Anyone may leave; admins may remove
+// members, owners anyone. The last owner can't leave or be removed.
+func (s *orgServer) RemoveMember(ctx context.Context, in *gv1.OrgMemberRequest) (*gv1.Empty, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockOrg(ctx, tx, in.OrgId); err != nil {
+		return nil, err
+	}
+
+	myRole, err := memberRole(ctx, tx, in.OrgId, c.UID)
+	if err != nil {
+		return nil, err
+	}
+	theirRole, err := memberRole(ctx, tx, in.OrgId, in.UserId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "member not found")
+	}
+
+	if in.UserId != c.UID {
+		need := orgAdmin
+		if theirRole != orgMember {
+			need = orgOwner
+		}
+		if orgRank[myRole] < orgRank[need] {
+			return nil, status.Errorf(codes.PermissionDenied, "requires org role %s", need)
+		}
+	}
+	if theirRole == orgOwner {
+		if err := keepAnOwner(ctx, tx, in.OrgId); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM org_members WHERE org_id = $1 AND user_id = $2`, in.OrgId, in.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	log.Printf("org member removed: org=%d uid=%d by=%d", in.OrgId, in.UserId, c.UID)
+	s.record(ctx, c.UID, "auth.org_member_removed", userTarget(in.UserId), map[string]string{"org": fmt.Sprint(in.OrgId)})
+
+	return &gv1.Empty{}, nil
+}
+
+// SetMemberRole changes a member's role. Only owners may do this.
+func (s *orgServer) SetMemberRole(ctx context.Context, in *gv1.OrgMemberRequest) (*gv1.Empty, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := orgRank[in.Role]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown org role %q", in.Role)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockOrg(ctx, tx, in.OrgId); err != nil {
+		return nil, err
+	}
+
+	if _, err := requireOrgRole(ctx, tx, in.OrgId, c.UID, orgOwner); err != nil {
+		return nil, err
+	}
+	theirRole, err := memberRole(ctx, tx, in.OrgId, in.UserId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "member not found")
+	}
+	if theirRole == orgOwner && in.Role != orgOwner {
+		if err := keepAnOwner(ctx, tx, in.OrgId); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE org_members SET role = $3 WHERE org_id = $1 AND user_id = $2`,
+		in.OrgId, in.UserId, in.Role,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	log.Printf("org role changed: org=%d uid=%d role=%s by=%d", in.OrgId, in.UserId, in.Role, c.UID)
+	s.record(ctx, c.UID, "auth.org_role_changed", userTarget(in.UserId),
+		map[string]string{"org": fmt.Sprint(in.OrgId), "role": in.Role})
+
+	return &gv1.Empty{}, nil
+}
+
+// lockOrg serializes membership changes within an org, so two owners can't
+// demote each other at the same time and leave it without one.
+func lockOrg(ctx context.Context, tx pgx.Tx, org int64) error {
+	var id int64
+	err := tx.QueryRow(ctx, `SELECT id FROM orgs WHERE id = $1 FOR UPDATE`, org).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return status.Error(codes.NotFound, "organization not found")
+	}
+
+	return err
+}
+
+// keepAnOwner fails if org has only one owner left.
+func keepAnOwner(ctx context.Context, tx pgx.Tx, org int64) error {
+	var owners int
+	err := tx.QueryRow(ctx,
+		`SELECT count(*) FROM org_members WHERE org_id = $1 AND role = $2`,
+		org, orgOwner,
+	).Scan(&owners)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return status.Error(codes.FailedPrecondition, "an organization needs at least one owner")
+	}
+
+	return nil
+}
+
+// IssueOrgToken mints an access token scoped to an org the caller belongs
+// to, in the caller's current session. Verify re-checks the membership on
+// every use and reports the current org role.
+func (s *orgServer) IssueOrgToken(ctx context.Context, in *gv1.OrgRequest) (*gv1.Token, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := memberRole(ctx, s.db, in.OrgId, c.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, exp, err := s.signAccessToken(tokenClaims{
+		UID:     c.UID,
+		SID:     c.SID,
+		EV:      c.EV,
+		Role:    c.Role,
+		Email:   c.Email,
+		Org:     in.OrgId,
+		OrgRole: role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &gv1.Token{AccessToken: signed, ExpiresAt: exp.UTC().Format(time.RFC3339)}, nil
+}
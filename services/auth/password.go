@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Passwords are hashed with argon2id and stored in the PHC string format:
+//
+//	$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>
+//
+// so each hash carries its own parameters. bcrypt hashes from before argon2id
+// still verify; Login rehashes them (and argon2id hashes with weaker
+// parameters than configured) with the current settings.
+
+// argonParams are the argon2id cost parameters.
+type argonParams struct {
+	Time    uint32 // iterations
+	Memory  uint32 // KiB
+	Threads uint8
+}
+
+const (
+	argonSaltLen = 16
+	argonKeyLen  = 32
+)
+
+// loadArgonParams reads ARGON2_TIME, ARGON2_MEMORY_KIB and ARGON2_THREADS.
+// The defaults follow RFC 9106's second recommended option.
+func loadArgonParams() (argonParams, error) {
+	t, err := strconv.ParseUint(env("ARGON2_TIME", "3"), 10, 32)
+	if err != nil || t < 1 {
+		return argonParams{}, fmt.Errorf("invalid ARGON2_TIME")
+	}
+	m, err := strconv.ParseUint(env("ARGON2_MEMORY_KIB", "65536"), 10, 32)
+	if err != nil || m < 8*1024 {
+		return argonParams{}, fmt.Errorf("invalid ARGON2_MEMORY_KIB (minimum 8192)")
+	}
+	p, err := strconv.ParseUint(env("ARGON2_THREADS", "4"), 10, 8)
+	if err != nil || p < 1 {
+		return argonParams{}, fmt.Errorf("invalid ARGON2_THREADS")
+	}
+
+	return argonParams{Time: uint32(t), Memory: uint32(m), Threads: uint8(p)}, nil
+}
+
+// hashPassword hashes pw with argon2id and the configured parameters.
+func (s *server) hashPassword(pw string) (string, error) {
+	salt := make([]byte, argonSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	p := s.argon
+	key := argon2.IDKey([]byte(pw), salt, p.Time, p.Memory, p.Threads, argonKeyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Time, p.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// verifyPassword reports whether pw matches hash, and whether hash should be
+// replaced because it uses an old algorithm or weaker parameters than
+// configured. An empty hash (accounts created through SSO) never matches.
+func (s *server) verifyPassword(hash, pw string) (ok, rehash bool) {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		p, salt, key, err := parseArgon2id(hash)
+		if err != nil {
+			return false, false
+		}
+
+		got := argon2.IDKey([]byte(pw), salt, p.Time, p.Memory, p.Threads, uint32(len(key)))
+		if subtle.ConstantTimeCompare(got, key) != 1 {
+			return false, false
+		}
+
+		weaker := p.Time < s.argon.Time || p.Memory < s.argon.Memory || p.Threads < s.argon.Threads ||
+			len(key) < argonKeyLen
+		return true, weaker
+
+	case strings.HasPrefix(hash, "$2"):
+		// bcrypt's compare is constant-time too.
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(pw)) != nil {
+			return false, false
+		}
+		return true, true
+	}
+
+	return false, false
+}
+
+// newDummyHash hashes a random password nobody knows, for dummyVerify.
+func (s *server) newDummyHash() (string, error) {
+	pw := make([]byte, 32)
+	if _, err := rand.Read(pw); err != nil {
+		return "", err
+	}
+
+	return s.hashPassword(string(pw))
+}
+
+// dummyVerify spends as long as checking pw against a real hash does, for
+// logins that fail before there is one to check (unknown or deleted email,
+// no password). Otherwise response time would tell whether an account
+// exists, which the throttle and the emailed-link endpoints take care not to.
+func (s *server) dummyVerify(pw string) {
+	s.verifyPassword(s.dummyHash, pw)
+}
+
+// upgradePasswordHash replaces uid's password hash with one using the current
+// settings. It is best-effort: the login has already succeeded. The update
+// only applies if the hash is still the one that was verified, so it can't
+// undo a password change that raced with the login.
+func (s *server) upgradePasswordHash(ctx context.Context, uid int64, old, pw string) {
+	hash, err := s.hashPassword(pw)
+	if err != nil {
+		log.Printf("rehash password uid=%d: %v", uid, err)
+		return
+	}
+
+	_, err = s.db.Exec(ctx,
+		`UPDATE users SET password_hash = $1 WHERE id = $2 AND password_hash = $3`,
+		hash, uid, old,
+	)
+	if err != nil {
+		log.Printf("rehash password uid=%d: %v", uid, err)
+	}
+}
+
+var errBadPasswordHash = errors.New("malformed argon2id hash")
+
+// parseArgon2id splits a PHC-format argon2id hash into its parts.
+func parseArgon2id(hash string) (p argonParams, salt, key []byte, err error) {
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return p, nil, nil, errBadPasswordHash
+	}
+
+	var v int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &v); err != nil || v != argon2.Version {
+		return p, nil, nil, errBadPasswordHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Threads); err != nil {
+		return p, nil, nil, errBadPasswordHash
+	}
+	if p.Time < 1 || p.Threads < 1 {
+		return p, nil, nil, errBadPasswordHash
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return p, nil, nil, errBadPasswordHash
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(key) == 0 {
+		return p, nil, nil, errBadPasswordHash
+	}
+
+	return p, salt, key, nil
+}
@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestDummyHash(t *testing.T) {
+	s := &server{argon: argonParams{Time: 1, Memory: 8 * 1024, Threads: 1}}
+
+	h, err := s.newDummyHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Same cost as a real hash, so a failed login takes as long either way.
+	p, _, _, err := parseArgon2id(h)
+	if err != nil || p != s.argon {
+		t.Fatalf("dummy hash params = %+v, %v; want %+v", p, err, s.argon)
+	}
+
+	for _, pw := range []string{"", "password", string(make([]byte, 32))} {
+		if ok, _ := s.verifyPassword(h, pw); ok {
+			t.Errorf("dummy hash matches %q", pw)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// patPrefix marks personal access tokens so they are recognisable in logs,
+// secret scanners and the gateway (which routes them to Verify instead of
+// checking them as JWTs).
+const patPrefix = "gdp_"
+
+// patScopes are the scopes a personal access token can be granted.
+var patScopes = []string{"files:read", "files:write", "files:delete"}
+
+// maxPATsPerUser keeps a runaway script from minting tokens forever.
+const maxPATsPerUser = 50
+
+// CreateAccessToken mints a named personal access token for the caller.
+// Only an interactive session may do this; a PAT can't mint more PATs.
+func (s *server) CreateAccessToken(ctx context.Context, in *gv1.CreateAccessTokenRequest) (*gv1.CreatedAccessToken, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(in.Name)
+	if name == "" || len(name) > 100 {
+		return nil, status.Error(codes.InvalidArgument, "name must be 1-100 characters")
+	}
+	if len(in.Scopes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one scope is required")
+	}
+	for _, sc := range in.Scopes {
+		if !slices.Contains(patScopes, sc) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown scope %q", sc)
+		}
+	}
+	if in.ExpiresInDays < 0 {
+		return nil, status.Error(codes.InvalidArgument, "expires_in_days must not be negative")
+	}
+
+	var n int
+	err = s.db.QueryRow(ctx,
+		`SELECT count(*) FROM personal_access_tokens WHERE user_id = $1 AND revoked_at IS NULL`,
+		c.UID,
+	).Scan(&n)
+	if err != nil {
+		return nil, err
+	}
+	if n >= maxPATsPerUser {
+		return nil, status.Errorf(codes.ResourceExhausted, "at most %d active tokens", maxPATsPerUser)
+	}
+
+	secret, err := randToken()
+	if err != nil {
+		return nil, err
+	}
+	tok := patPrefix + secret
+
+	var expires *time.Time
+	if in.ExpiresInDays > 0 {
+		t := time.Now().AddDate(0, 0, int(in.ExpiresInDays))
+		expires = &t
+	}
+
+	scopes := slices.Compact(slices.Sorted(slices.Values(in.Scopes)))
+	info := &gv1.AccessTokenInfo{Name: name, Scopes: scopes, Prefix: tok[:len(patPrefix)+6]}
+
+	var created time.Time
+	err = s.db.QueryRow(ctx, `
+INSERT INTO personal_access_tokens(user_id, name, token_hash, token_prefix, scopes, expires_at)
+VALUES($1, $2, $3, $4, $5, $6)
+RETURNING id, created_at`,
+		c.UID, name, hashToken(tok), info.Prefix, scopes, expires,
+	).Scan(&info.Id, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	info.CreatedAt = created.UTC().Format(time.RFC3339)
+	info.ExpiresAt = fmtTime(expires)
+
+	log.Printf("access token created: uid=%d id=%d scopes=%v", c.UID, info.Id, scopes)
+	s.record(ctx, c.UID, "auth.pat_created", fmt.Sprintf("pat:%d", info.Id),
+		map[string]string{"scopes": strings.Join(scopes, " ")})
+
+	return &gv1.CreatedAccessToken{Info: info, Token: tok}, nil
+}
+
+// ListAccessTokens lists the caller's active tokens (never the secrets).
+func (s *server) ListAccessTokens(ctx context.Context, _ *gv1.Empty) (*gv1.AccessTokenList, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `
+SELECT id, name, scopes, token_prefix, created_at, expires_at, last_used_at
+FROM personal_access_tokens
+WHERE user_id = $1
+  AND revoked_at IS NULL
+ORDER BY created_at DESC`, c.UID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := &gv1.AccessTokenList{}
+	for rows.Next() {
+		var (
+			t                 gv1.AccessTokenInfo
+			created           time.Time
+			expires, lastUsed *time.Time
+		)
+		if err := rows.Scan(&t.Id, &t.Name, &t.Scopes, &t.Prefix, &created, &expires, &lastUsed); err != nil {
+			return nil, err
+		}
+		t.CreatedAt = created.UTC().Format(time.RFC3339)
+		t.ExpiresAt = fmtTime(expires)
+		t.LastUsedAt = fmtTime(lastUsed)
+		out.Tokens = append(out.Tokens, &t)
+	}
+
+	return out, rows.Err()
+}
+
+// RevokeAccessToken revokes one of the caller's tokens. It takes effect on
+// the token's next use, since every PAT request goes through Verify.
+func (s *server) RevokeAccessToken(ctx context.Context, in *gv1.RevokeAccessTokenRequest) (*gv1.Empty, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ct, err := s.db.Exec(ctx, `
+UPDATE personal_access_tokens
+SET revoked_at = now()
+WHERE id = $1
+  AND user_id = $2
+  AND revoked_at IS NULL`, in.Id, c.UID)
+	if err != nil {
+		return nil, err
+	}
+	if ct.RowsAffected() == 0 {
+		return nil, status.Error(codes.NotFound, "token not found")
+	}
+	s.record(ctx, c.UID, "auth.pat_revoked", fmt.Sprintf("pat:%d", in.Id), nil)
+
+	return &gv1.Empty{}, nil
+}
+
+// verifyPAT checks a personal access token and returns its user and scopes.
+func (s *server) verifyPAT(ctx context.Context, tok string) (*gv1.User, error) {
+	var (
+		id      int64
+		u       gv1.User
+		expires *time.Time
+	)
+	err := s.db.QueryRow(ctx, `
+SELECT t.id, t.scopes, t.expires_at, u.id, u.email, u.email_verified_at IS NOT NULL, u.role
+FROM personal_access_tokens t
+JOIN users u ON u.id = t.user_id
+WHERE t.token_hash = $1
+  AND t.revoked_at IS NULL
+  AND u.suspended_at IS NULL
+  AND (u.delete_after IS NULL OR u.delete_after > now())`,
+		hashToken(tok),
+	).Scan(&id, &u.Scopes, &expires, &u.Id, &u.Email, &u.EmailVerified, &u.Role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, grpcErr("invalid token")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if expires != nil && time.Now().After(*expires) {
+		return nil, grpcErr("token expired")
+	}
+
+	// Minute granularity is plenty for "last used" and saves a write per request.
+	_, err = s.db.Exec(ctx, `
+UPDATE personal_access_tokens
+SET last_used_at = now()
+WHERE id = $1
+  AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute')`, id)
+	if err != nil {
+		log.Printf("touch access token id=%d: %v", id, err)
+	}
+
+	return &u, nil
+}
+
+// fmtTime renders an optional timestamp; nil becomes "".
+func fmtTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
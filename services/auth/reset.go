@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// minPasswordLen is the shortest password we accept when one is (re)set.
+const minPasswordLen = 8
+
+// RequestPasswordReset emails a single-use reset link to the account owner.
+// It returns success whether or not the email exists.
+func (s *server) RequestPasswordReset(ctx context.Context, in *gv1.PasswordResetRequest) (*gv1.Empty, error) {
+	email := emailKey(in.Email)
+	if err := s.limitMail(ctx, purposePasswordReset, email); err != nil {
+		return nil, err
+	}
+
+	var uid int64
+	err := s.db.QueryRow(ctx, `SELECT id FROM users WHERE email = $1`, email).Scan(&uid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &gv1.Empty{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sendPasswordReset(ctx, uid, email); err != nil {
+		return nil, err
+	}
+
+	return &gv1.Empty{}, nil
+}
+
+// sendPasswordReset issues a reset token for uid and mails the link to email.
+func (s *server) sendPasswordReset(ctx context.Context, uid int64, email string) error {
+	tok, err := issueOneTimeToken(ctx, s.db, uid, purposePasswordReset, s.resetTTL)
+	if err != nil {
+		return err
+	}
+
+	link := s.baseURL + "/reset-password?token=" + url.QueryEscape(tok)
+	s.deliver(mail{
+		To:      email,
+		Subject: "Reset your GoDrive password",
+		Body: fmt.Sprintf(
+			"Someone asked to reset the password for your GoDrive account.\n\n"+
+				"Open this link to choose a new one:\n%s\n\n"+
+				"The link expires in %s and works once. If this wasn't you, ignore this email.\n",
+			link, s.resetTTL),
+	})
+
+	return nil
+}
+
+// ResetPassword redeems a reset token and sets a new password. All existing
+// sessions are revoked, since a reset usually means the old password leaked.
+func (s *server) ResetPassword(ctx context.Context, in *gv1.ResetPasswordRequest) (*gv1.Empty, error) {
+	if len(in.NewPassword) < minPasswordLen {
+		return nil, status.Errorf(codes.InvalidArgument, "password must be at least %d characters", minPasswordLen)
+	}
+
+	hash, err := s.hashPassword(in.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	uid, err := consumeOneTimeToken(ctx, tx, purposePasswordReset, in.Token)
+	if errors.Is(err, errBadOneTimeToken) {
+		return nil, status.Error(codes.InvalidArgument, "invalid or expired reset token")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE users SET password_hash = $1 WHERE id = $2`, hash, uid); err != nil {
+		return nil, err
+	}
+
+	ev, err := s.revokeUserTokens(ctx, tx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	s.publishRevocation(ev)
+
+	// Proving control of the mailbox is enough to lift a lockout.
+	var email string
+	if err := s.db.QueryRow(ctx, `SELECT email FROM users WHERE id = $1`, uid).Scan(&email); err == nil {
+		s.clearLoginFailures(ctx, email, "password_reset")
+	}
+
+	log.Printf("password reset: uid=%d", uid)
+	s.record(ctx, uid, "auth.password_reset", userTarget(uid), nil)
+
+	return &gv1.Empty{}, nil
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+// revocationSubject is where revocations are broadcast. Gateways verify
+// tokens locally, so this is how they learn a token is no longer good.
+const revocationSubject = "godrive.auth.revoked"
+
+// revocation is the NATS payload for revocationSubject. It names a single
+// token (JTI), every token of a session (SessionID), or every token a user was
+// issued before NotBefore (UserID); subscribers apply whichever are set.
+type revocation struct {
+	JTI       string    `json:"jti,omitempty"`
+	SessionID string    `json:"sid,omitempty"`
+	UserID    int64     `json:"uid,omitempty"`
+	NotBefore time.Time `json:"not_before,omitempty"`
+	// ExpiresAt is when the revocation stops mattering because every token
+	// it covers has expired anyway; subscribers can forget it after that.
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// parseAccessToken checks the signature and expiry of an access token and
+// returns its claims. It does not consult the revocation store; see checkRevoked.
+func (s *server) parseAccessToken(raw string) (*tokenClaims, error) {
+	claims := &tokenClaims{}
+
+	_, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := s.keys.publicKey(kid)
+		if !ok {
+			return nil, errors.New("unknown kid")
+		}
+		return key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}), jwt.WithIssuedAt(), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, grpcErr("invalid token")
+	}
+
+	return claims, nil
+}
+
+// checkRevoked rejects tokens that were logged out, whose session was
+// revoked, that predate a "log out everywhere", or whose user no longer
+// exists, is past its deletion date or is suspended. On success it returns
+// the token's user as currently stored.
+func (s *server) checkRevoked(ctx context.Context, c *tokenClaims) (*gv1.User, error) {
+	var revoked bool
+	err := s.db.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = $1)`,
+		c.ID,
+	).Scan(&revoked)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, grpcErr("token revoked")
+	}
+
+	// Tokens from before sessions were recorded have no row; only an
+	// explicitly revoked session counts against them.
+	if c.SID != "" {
+		err := s.db.QueryRow(ctx,
+			`SELECT EXISTS(SELECT 1 FROM sessions WHERE id = $1::uuid AND revoked_at IS NOT NULL)`,
+			c.SID,
+		).Scan(&revoked)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, grpcErr("session revoked")
+		}
+	}
+
+	var (
+		u          = &gv1.User{Id: c.UID}
+		validAfter *time.Time
+		suspended  bool
+	)
+	err = s.db.QueryRow(ctx, `
+SELECT email, email_verified_at IS NOT NULL, role, tokens_valid_after, suspended_at IS NOT NULL
+FROM users
+WHERE id = $1
+  AND (delete_after IS NULL OR delete_after > now())`,
+		c.UID,
+	).Scan(&u.Email, &u.EmailVerified, &u.Role, &validAfter, &suspended)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, grpcErr("invalid token")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if suspended {
+		return nil, grpcErr("account suspended")
+	}
+
+	// iat has second precision; a token from the same second as the cutoff is
+	// treated as issued before it.
+	if validAfter != nil && c.IssuedAt.Time.Before(*validAfter) {
+		return nil, grpcErr("token revoked")
+	}
+
+	// An org-scoped token is only good while its user is still a member; the
+	// role reported is the current one, not the one in the claim.
+	if c.Org != 0 {
+		err := s.db.QueryRow(ctx,
+			`SELECT role FROM org_members WHERE org_id = $1 AND user_id = $2`,
+			c.Org, c.UID,
+		).Scan(&u.OrgRole)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, grpcErr("no longer a member of the organization")
+		}
+		if err != nil {
+			return nil, err
+		}
+		u.OrgId = c.Org
+	}
+
+	return u, nil
+}
+
+// Logout revokes the presented access token and ends the session it was
+// minted in. If a refresh token is supplied, its family is revoked too.
+func (s *server) Logout(ctx context.Context, in *gv1.Token) (*gv1.Empty, error) {
+	c, err := s.parseAccessToken(in.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.db.Exec(ctx, `
+INSERT INTO revoked_tokens(jti, user_id, expires_at)
+VALUES($1, $2, $3)
+ON CONFLICT (jti) DO NOTHING`,
+		c.ID, c.UID, c.ExpiresAt.Time,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.SID != "" {
+		if err := endSession(ctx, s.db, c.UID, c.SID); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+	}
+
+	if in.RefreshToken != "" {
+		_, err = s.db.Exec(ctx, `
+UPDATE refresh_tokens
+SET revoked_at = now()
+WHERE family_id = (SELECT family_id FROM refresh_tokens WHERE token_hash = $1 AND user_id = $2)
+  AND revoked_at IS NULL`, hashToken(in.RefreshToken), c.UID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Revoked jtis are only interesting until the token would have expired.
+	if _, err := s.db.Exec(ctx, `DELETE FROM revoked_tokens WHERE expires_at < now()`); err != nil {
+		log.Printf("prune revoked_tokens: %v", err)
+	}
+
+	s.publishRevocation(revocation{JTI: c.ID, SessionID: c.SID, ExpiresAt: time.Now().Add(s.accessTTL)})
+	s.record(ctx, c.UID, "auth.logout", userTarget(c.UID), map[string]string{"session": c.SID})
+
+	return &gv1.Empty{}, nil
+}
+
+// LogoutAllSessions invalidates every access and refresh token the caller
+// holds, on every device.
+func (s *server) LogoutAllSessions(ctx context.Context, in *gv1.Token) (*gv1.Empty, error) {
+	c, err := s.parseAccessToken(in.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.checkRevoked(ctx, c); err != nil {
+		return nil, err
+	}
+
+	ev, err := s.revokeUserTokens(ctx, s.db, c.UID)
+	if err != nil {
+		return nil, err
+	}
+	s.publishRevocation(ev)
+	s.record(ctx, c.UID, "auth.logout_all", userTarget(c.UID), nil)
+
+	return &gv1.Empty{}, nil
+}
+
+// revokeUserTokens kills every access and refresh token issued to uid so far.
+// It returns the event to broadcast; callers publish it once q has committed.
+func (s *server) revokeUserTokens(ctx context.Context, q dbtx, uid int64) (revocation, error) {
+	var cutoff time.Time
+	err := q.QueryRow(ctx,
+		`UPDATE users SET tokens_valid_after = now() WHERE id = $1 RETURNING tokens_valid_after`,
+		uid,
+	).Scan(&cutoff)
+	if err != nil {
+		return revocation{}, err
+	}
+
+	_, err = q.Exec(ctx, `
+UPDATE refresh_tokens
+SET revoked_at = now()
+WHERE user_id = $1
+  AND revoked_at IS NULL`, uid)
+	if err != nil {
+		return revocation{}, err
+	}
+
+	_, err = q.Exec(ctx, `UPDATE sessions SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`, uid)
+	if err != nil {
+		return revocation{}, err
+	}
+
+	return revocation{
+		UserID:    uid,
+		NotBefore: cutoff,
+		ExpiresAt: cutoff.Add(s.accessTTL),
+	}, nil
+}
+
+// publishRevocation broadcasts a revocation. Failures are logged, not
+// returned: the revocation is already durable for Verify, and a gateway that
+// misses it only honours the token until it expires (see ACCESS_TOKEN_TTL).
+func (s *server) publishRevocation(r revocation) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		log.Printf("marshal revocation: %v", err)
+		return
+	}
+
+	if err := s.nc.Publish(revocationSubject, b); err != nil {
+		log.Printf("publish revocation: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"godrive/internal/rbac"
+	gv1 "godrive/proto/godrive/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rolePolicy lists the RPCs restricted by role; everything else is open to
+// any caller (and authenticates them itself where needed).
+var rolePolicy = rbac.Policy{
+	gv1.AuthService_AdminResetMFA_FullMethodName: {rbac.Admin},
+	gv1.AuthService_SetUserRole_FullMethodName:   {rbac.Admin},
+
+	gv1.AdminService_SearchUsers_FullMethodName:          {rbac.Admin, rbac.Auditor},
+	gv1.AdminService_GetUserUsage_FullMethodName:         {rbac.Admin, rbac.Auditor},
+	gv1.AdminService_SuspendUser_FullMethodName:          {rbac.Admin},
+	gv1.AdminService_UnsuspendUser_FullMethodName:        {rbac.Admin},
+	gv1.AdminService_ForceLogout_FullMethodName:          {rbac.Admin},
+	gv1.AdminService_TriggerPasswordReset_FullMethodName: {rbac.Admin},
+	gv1.AdminService_CreateSignupInvite_FullMethodName:   {rbac.Admin},
+	gv1.AdminService_QueryAuditLog_FullMethodName:        {rbac.Admin, rbac.Auditor},
+	gv1.AdminService_VerifyAuditLog_FullMethodName:       {rbac.Admin, rbac.Auditor},
+}
+
+// callerRole resolves the role of the user behind an RPC for the rbac interceptor.
+// The role is read from the (non-revoked) token; changing a role revokes the
+// user's tokens, so a stale claim can't outlive a demotion.
+func (s *server) callerRole(ctx context.Context) (rbac.Role, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return rbac.Parse(c.Role), nil
+}
+
+// SetUserRole changes a user's role. The user is logged out everywhere so
+// their next token carries the new role.
+func (s *server) SetUserRole(ctx context.Context, in *gv1.SetUserRoleRequest) (*gv1.Empty, error) {
+	role := rbac.Role(in.Role)
+	if !role.Valid() {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown role %q", in.Role)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	ct, err := tx.Exec(ctx, `UPDATE users SET role = $1 WHERE id = $2`, string(role), in.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if ct.RowsAffected() == 0 {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	ev, err := s.revokeUserTokens(ctx, tx, in.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	s.publishRevocation(ev)
+
+	log.Printf("role changed: uid=%d role=%s", in.UserId, role)
+	s.record(ctx, s.actor(ctx), "auth.role_changed", userTarget(in.UserId), map[string]string{"role": string(role)})
+
+	return &gv1.Empty{}, nil
+}
+
+// bootstrapAdmin promotes the account with the given email to admin, so a
+// fresh deployment has someone who can hand out roles. Only a verified email
+// counts: anyone may register an address they don't own. It is a no-op until
+// the account exists and is verified; it is retried on every start.
+func bootstrapAdmin(ctx context.Context, s *server, email string) error {
+	if email == "" {
+		return nil
+	}
+
+	ct, err := s.db.Exec(ctx, `
+UPDATE users
+SET role = 'admin'
+WHERE email = lower($1)
+  AND email_verified_at IS NOT NULL
+  AND role <> 'admin'`, email)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() > 0 {
+		log.Printf("bootstrap: promoted %s to admin", email)
+		return nil
+	}
+
+	var unverified bool
+	err = s.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM users WHERE email = lower($1) AND email_verified_at IS NULL)`,
+		email,
+	).Scan(&unverified)
+	if err != nil {
+		return err
+	}
+	if unverified {
+		log.Printf("bootstrap: not promoting %s to admin until its email is verified", email)
+	}
+
+	return nil
+}
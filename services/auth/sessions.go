@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// maxUserAgentLen caps what we store from a client-supplied header.
+const maxUserAgentLen = 512
+
+// clientInfo returns the end user's user agent and IP, as forwarded by the
+// gateway. Direct gRPC callers get their own user agent and peer address.
+func clientInfo(ctx context.Context) (ua, ip string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, k := range []string{"x-user-agent", "user-agent"} {
+		if v := md.Get(k); len(v) > 0 && v[0] != "" {
+			ua = v[0]
+			break
+		}
+	}
+	if len(ua) > maxUserAgentLen {
+		ua = ua[:maxUserAgentLen]
+	}
+
+	return ua, clientIP(ctx)
+}
+
+// endSession marks a session revoked and revokes its refresh tokens. It
+// returns pgx.ErrNoRows if uid has no such active session.
+func endSession(ctx context.Context, q dbtx, uid int64, sid string) error {
+	ct, err := q.Exec(ctx, `
+UPDATE sessions
+SET revoked_at = now()
+WHERE id::text = $1
+  AND user_id = $2
+  AND revoked_at IS NULL`, sid, uid)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, `
+UPDATE refresh_tokens
+SET revoked_at = now()
+WHERE family_id::text = $1
+  AND user_id = $2
+  AND revoked_at IS NULL`, sid, uid)
+	if err != nil {
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListSessions lists the caller's active sessions, most recently used first.
+// A session whose refresh token could no longer be used is not listed.
+func (s *server) ListSessions(ctx context.Context, _ *gv1.Empty) (*gv1.SessionList, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `
+SELECT id::text, user_agent, ip, created_at, last_seen_at
+FROM sessions
+WHERE user_id = $1
+  AND revoked_at IS NULL
+  AND last_seen_at > $2
+ORDER BY last_seen_at DESC`, c.UID, time.Now().Add(-s.refreshTTL))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := &gv1.SessionList{}
+	for rows.Next() {
+		var (
+			si                gv1.SessionInfo
+			created, lastSeen time.Time
+		)
+		if err := rows.Scan(&si.Id, &si.UserAgent, &si.Ip, &created, &lastSeen); err != nil {
+			return nil, err
+		}
+		si.CreatedAt = created.UTC().Format(time.RFC3339)
+		si.LastSeenAt = lastSeen.UTC().Format(time.RFC3339)
+		si.Current = si.Id == c.SID
+		out.Sessions = append(out.Sessions, &si)
+	}
+
+	return out, rows.Err()
+}
+
+// RevokeSession signs one of the caller's devices out. Its refresh tokens
+// stop working at once; its access tokens are rejected by Verify and, via the
+// revocation broadcast, by gateways.
+func (s *server) RevokeSession(ctx context.Context, in *gv1.RevokeSessionRequest) (*gv1.Empty, error) {
+	c, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = endSession(ctx, s.db, c.UID, in.Id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "session not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishRevocation(revocation{SessionID: in.Id, ExpiresAt: time.Now().Add(s.accessTTL)})
+	s.record(ctx, c.UID, "auth.session_revoked", userTarget(c.UID), map[string]string{"session": in.Id})
+
+	return &gv1.Empty{}, nil
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	netmail "net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Signup modes, set with SIGNUP_MODE.
+const (
+	signupOpen     = "open"     // anyone may register
+	signupDomains  = "domains"  // only addresses in SIGNUP_ALLOWED_DOMAINS
+	signupInvite   = "invite"   // only with an invite code from an admin
+	signupDisabled = "disabled" // no new accounts
+)
+
+// maxEmailLen is the longest address SMTP can carry (RFC 5321).
+const maxEmailLen = 254
+
+// pgUniqueViolation is Postgres' unique_violation error code.
+const pgUniqueViolation = "23505"
+
+// signupPolicy decides who may create an account. It applies to password
+// signups and to accounts created on first SSO login.
+type signupPolicy struct {
+	mode      string
+	domains   []string      // lowercase, for signupDomains
+	inviteTTL time.Duration // lifetime of invite codes
+}
+
+// loadSignupPolicy reads SIGNUP_MODE, SIGNUP_ALLOWED_DOMAINS (comma-separated)
+// and SIGNUP_INVITE_TTL.
+func loadSignupPolicy() (signupPolicy, error) {
+	p := signupPolicy{mode: strings.ToLower(env("SIGNUP_MODE", signupOpen))}
+
+	switch p.mode {
+	case signupOpen, signupInvite, signupDisabled:
+	case signupDomains:
+		for _, d := range strings.Split(env("SIGNUP_ALLOWED_DOMAINS", ""), ",") {
+			if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+				p.domains = append(p.domains, strings.TrimPrefix(d, "@"))
+			}
+		}
+		if len(p.domains) == 0 {
+			return p, errors.New("SIGNUP_MODE=domains needs SIGNUP_ALLOWED_DOMAINS")
+		}
+	default:
+		return p, fmt.Errorf("unknown SIGNUP_MODE %q", p.mode)
+	}
+
+	ttl, err := time.ParseDuration(env("SIGNUP_INVITE_TTL", "168h"))
+	if err != nil {
+		return p, fmt.Errorf("invalid SIGNUP_INVITE_TTL: %v", err)
+	}
+	p.inviteTTL = ttl
+
+	return p, nil
+}
+
+// normalizeEmail checks that s is a bare address (no display name or angle
+// brackets) with a dotted domain, and returns it lowercased.
+func normalizeEmail(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	errInvalid := status.Error(codes.InvalidArgument, "invalid email")
+
+	if s == "" || len(s) > maxEmailLen {
+		return "", errInvalid
+	}
+	a, err := netmail.ParseAddress(s)
+	if err != nil || a.Name != "" || a.Address != s {
+		return "", errInvalid
+	}
+
+	local, domain, _ := strings.Cut(a.Address, "@")
+	if len(local) > 64 || !strings.Contains(domain, ".") ||
+		strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		return "", errInvalid
+	}
+
+	return strings.ToLower(a.Address), nil
+}
+
+// admit decides whether email may get a new account. In invite mode the code
+// is redeemed within q, so it is only spent if the signup commits. Org
+// invitations don't count: any user can create an org and invite anyone.
+// It returns the redeemed invite's ID, or 0.
+func (p signupPolicy) admit(ctx context.Context, q dbtx, email, code string) (int64, error) {
+	switch p.mode {
+	case signupDisabled:
+		return 0, status.Error(codes.PermissionDenied, "signup is disabled")
+
+	case signupDomains:
+		_, domain, _ := strings.Cut(email, "@")
+		if !slices.Contains(p.domains, domain) {
+			return 0, status.Error(codes.PermissionDenied, "email domain not allowed")
+		}
+
+	case signupInvite:
+		if code == "" {
+			return 0, status.Error(codes.PermissionDenied, "invite code required")
+		}
+
+		var id int64
+		err := q.QueryRow(ctx, `
+UPDATE signup_invites
+SET used_at = now()
+WHERE code_hash = $1
+  AND used_at IS NULL
+  AND expires_at > now()
+  AND (email IS NULL OR email = $2)
+RETURNING id`, hashToken(code), email).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, status.Error(codes.PermissionDenied, "invalid or expired invite code")
+		}
+		return id, err
+	}
+
+	return 0, nil
+}
+
+// SignUp creates a password account, subject to the signup mode. Refused
+// signups and taken emails count against the client IP like failed logins,
+// so neither invite codes nor the password hash can be hammered.
+func (s *server) SignUp(ctx context.Context, in *gv1.SignUpRequest) (*gv1.User, error) {
+	keys := ipKeys(ctx)
+	if err := s.checkThrottle(ctx, keys); err != nil {
+		return nil, err
+	}
+
+	email, err := normalizeEmail(in.Email)
+	if err != nil {
+		return nil, err
+	}
+	if len(in.Password) < minPasswordLen {
+		return nil, status.Errorf(codes.InvalidArgument, "password must be at least %d characters", minPasswordLen)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Admission first: a refused signup shouldn't cost a password hash.
+	invite, err := s.signup.admit(ctx, tx, email, strings.TrimSpace(in.InviteCode))
+	if err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			s.recordLoginFailure(ctx, keys)
+		}
+		return nil, err
+	}
+
+	// Always hash passwords; never store plaintext.
+	hash, err := s.hashPassword(in.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	// Insert user and return primary key.
+	var id int64
+	err = tx.QueryRow(ctx,
+		`INSERT INTO users(email, password_hash) VALUES($1, $2) RETURNING id`,
+		email, hash,
+	).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			s.recordLoginFailure(ctx, keys)
+			return nil, status.Error(codes.AlreadyExists, "email already registered")
+		}
+		return nil, err
+	}
+
+	if invite != 0 {
+		if _, err := tx.Exec(ctx, `UPDATE signup_invites SET used_by = $1 WHERE id = $2`, id, invite); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	details := map[string]string{"mode": s.signup.mode}
+	if invite != 0 {
+		details["invite"] = fmt.Sprint(invite)
+	}
+	s.record(ctx, id, "auth.signup", userTarget(id), details)
+
+	// A failed verification mail shouldn't fail signup; the user can ask for a resend.
+	if err := s.sendVerification(ctx, id, email); err != nil {
+		log.Printf("send verification uid=%d: %v", id, err)
+	}
+
+	// Build response. created_at is generated by DB; we present a client-friendly ISO string.
+	return &gv1.User{
+		Id:        id,
+		Email:     email,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// CreateSignupInvite mints a single-use signup code, optionally bound to one
+// address. Only its hash is stored, so the code is shown once.
+func (s *adminServer) CreateSignupInvite(ctx context.Context, in *gv1.CreateSignupInviteRequest) (*gv1.SignupInvite, error) {
+	var email *string
+	if strings.TrimSpace(in.Email) != "" {
+		e, err := normalizeEmail(in.Email)
+		if err != nil {
+			return nil, err
+		}
+		email = &e
+	}
+
+	code, err := randToken()
+	if err != nil {
+		return nil, err
+	}
+
+	inv := &gv1.SignupInvite{Code: code}
+	if email != nil {
+		inv.Email = *email
+	}
+
+	by := s.actor(ctx)
+	var expires time.Time
+	err = s.db.QueryRow(ctx, `
+INSERT INTO signup_invites(code_hash, email, created_by, expires_at)
+VALUES($1, $2, NULLIF($3::bigint, 0), $4)
+RETURNING id, expires_at`,
+		hashToken(code), email, by, time.Now().Add(s.signup.inviteTTL),
+	).Scan(&inv.Id, &expires)
+	if err != nil {
+		return nil, err
+	}
+	inv.ExpiresAt = expires.UTC().Format(time.RFC3339)
+
+	log.Printf("signup invite created: id=%d by=%d", inv.Id, by)
+	s.record(ctx, by, "auth.signup_invite_created", fmt.Sprintf("signup_invite:%d", inv.Id), nil)
+
+	return inv, nil
+}
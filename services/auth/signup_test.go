@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// invite is a signup_invites row in fakeInvites.
+type invite struct {
+	id    int64
+	email string // "" for any address
+	used  bool
+}
+
+// fakeInvites stands in for the database in admit: QueryRow redeems the
+// invite whose code hash and email match, as the UPDATE does.
+type fakeInvites map[string]*invite
+
+func (f fakeInvites) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errors.New("unexpected Exec")
+}
+
+func (f fakeInvites) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, errors.New("unexpected Query")
+}
+
+func (f fakeInvites) QueryRow(_ context.Context, _ string, args ...any) pgx.Row {
+	hash, email := args[0].(string), args[1].(string)
+	inv, ok := f[hash]
+	if !ok || inv.used || (inv.email != "" && inv.email != email) {
+		return idRow{err: pgx.ErrNoRows}
+	}
+	inv.used = true
+
+	return idRow{id: inv.id}
+}
+
+// idRow is the row of a RETURNING id.
+type idRow struct {
+	id  int64
+	err error
+}
+
+func (r idRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*int64) = r.id
+
+	return nil
+}
+
+func TestAdmit(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  signupPolicy
+		email   string
+		invite  string
+		want    int64
+		wantErr codes.Code
+	}{
+		{"open", signupPolicy{mode: signupOpen}, "a@example.com", "", 0, codes.OK},
+		{"open ignores code", signupPolicy{mode: signupOpen}, "a@example.com", "bogus", 0, codes.OK},
+		{"disabled", signupPolicy{mode: signupDisabled}, "a@example.com", "", 0, codes.PermissionDenied},
+		{"disabled with invite", signupPolicy{mode: signupDisabled}, "a@example.com", "open-code", 0, codes.PermissionDenied},
+		{"allowed domain", signupPolicy{mode: signupDomains, domains: []string{"corp.example", "example.com"}}, "a@example.com", "", 0, codes.OK},
+		{"other domain", signupPolicy{mode: signupDomains, domains: []string{"corp.example"}}, "a@example.com", "", 0, codes.PermissionDenied},
+		{"subdomain", signupPolicy{mode: signupDomains, domains: []string{"example.com"}}, "a@mail.example.com", "", 0, codes.PermissionDenied},
+		{"suffix", signupPolicy{mode: signupDomains, domains: []string{"example.com"}}, "a@badexample.com", "", 0, codes.PermissionDenied},
+		{"invite without code", signupPolicy{mode: signupInvite}, "a@example.com", "", 0, codes.PermissionDenied},
+		{"unknown invite", signupPolicy{mode: signupInvite}, "a@example.com", "nope", 0, codes.PermissionDenied},
+		{"open invite", signupPolicy{mode: signupInvite}, "a@example.com", "open-code", 1, codes.OK},
+		{"invite for email", signupPolicy{mode: signupInvite}, "a@example.com", "a-code", 2, codes.OK},
+		{"invite for other email", signupPolicy{mode: signupInvite}, "b@example.com", "a-code", 0, codes.PermissionDenied},
+		{"used invite", signupPolicy{mode: signupInvite}, "a@example.com", "used-code", 0, codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := fakeInvites{
+				hashToken("open-code"): {id: 1},
+				hashToken("a-code"):    {id: 2, email: "a@example.com"},
+				hashToken("used-code"): {id: 3, used: true},
+			}
+
+			got, err := tt.policy.admit(context.Background(), q, tt.email, tt.invite)
+			if c := status.Code(err); c != tt.wantErr {
+				t.Fatalf("admit(%q, %q) error = %v, want code %s", tt.email, tt.invite, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("admit(%q, %q) = %d, want %d", tt.email, tt.invite, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdmitSpendsInvite(t *testing.T) {
+	p := signupPolicy{mode: signupInvite}
+	q := fakeInvites{hashToken("once"): {id: 7}}
+
+	if id, err := p.admit(context.Background(), q, "a@example.com", "once"); err != nil || id != 7 {
+		t.Fatalf("first admit = %d, %v; want 7, nil", id, err)
+	}
+	if _, err := p.admit(context.Background(), q, "b@example.com", "once"); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("second admit error = %v, want PermissionDenied", err)
+	}
+}
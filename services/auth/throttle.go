@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Failed logins are counted per email and per client IP. After a few free
+// attempts each failure doubles the wait before the next try; enough of them
+// lock the key out for a while. Throttled attempts are refused before bcrypt
+// runs, so they cost us almost nothing.
+const (
+	loginFreeAttempts = 3                // failures before backoff kicks in
+	loginBackoffBase  = time.Second      // wait after the first counted failure
+	loginBackoffMax   = time.Minute      // backoff ceiling below a lockout
+	loginLockout      = 15 * time.Minute // how long a lockout lasts
+	loginFailureTTL   = time.Hour        // quiet period after which failures are forgotten
+
+	emailLockoutAfter = 10  // failures for one email before it is locked
+	ipLockoutAfter    = 100 // failures from one IP (many emails) before it is locked
+	mfaLockoutAfter   = 10  // wrong second factors for one user, across challenges
+)
+
+const (
+	lockoutSubject = "godrive.auth.lockout"
+	unlockSubject  = "godrive.auth.unlock"
+)
+
+// lockoutEvent is the NATS payload for lockoutSubject and unlockSubject.
+type lockoutEvent struct {
+	Kind     string     `json:"kind"` // "email", "ip" or "mfa" (a user ID)
+	Value    string     `json:"value"`
+	Failures int        `json:"failures,omitempty"`
+	Until    *time.Time `json:"until,omitempty"`  // lockouts only
+	Reason   string     `json:"reason,omitempty"` // unlocks: "expired", "login" or "password_reset"
+}
+
+// throttleKey is one counter: an email address, a client IP, or a user's
+// second factor.
+type throttleKey struct {
+	kind, value string
+	lockAfter   int
+}
+
+// loginKeys returns the counters a login attempt for email counts against.
+func loginKeys(ctx context.Context, email string) []throttleKey {
+	keys := []throttleKey{{"email", emailKey(email), emailLockoutAfter}}
+
+	return append(keys, ipKeys(ctx)...)
+}
+
+// emailKey is how an email, as typed at login, is counted and logged:
+// lowercased, and cut to the longest real address so whatever a client sends
+// can't bloat the throttle table or the audit log.
+func emailKey(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if len(email) > maxEmailLen {
+		email = strings.ToValidUTF8(email[:maxEmailLen], "")
+	}
+
+	return email
+}
+
+// ipKeys returns the client IP's counter, if the IP is known.
+func ipKeys(ctx context.Context) []throttleKey {
+	if ip := clientIP(ctx); ip != "" {
+		return []throttleKey{{"ip", ip, ipLockoutAfter}}
+	}
+
+	return nil
+}
+
+// mfaKey counts wrong second factors for uid. Unlike the email counter it
+// survives new challenges and password resets, and only a login that gets
+// through MFA clears it.
+func mfaKey(uid int64) throttleKey {
+	return throttleKey{"mfa", strconv.FormatInt(uid, 10), mfaLockoutAfter}
+}
+
+// clientIP is the end user's address as forwarded by the gateway, falling
+// back to the gRPC peer for direct callers.
+func clientIP(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("x-client-ip"); len(v) > 0 && v[0] != "" {
+			return v[0]
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+	}
+
+	return ""
+}
+
+// checkThrottle refuses the attempt if any of keys is backing off or locked.
+func (s *server) checkThrottle(ctx context.Context, keys []throttleKey) error {
+	var wait time.Duration
+	for _, k := range keys {
+		var until *time.Time
+		err := s.db.QueryRow(ctx,
+			`SELECT max(blocked_until) FROM login_throttle WHERE kind = $1 AND value = $2`,
+			k.kind, k.value,
+		).Scan(&until)
+		if err != nil {
+			return err
+		}
+		if until != nil {
+			wait = max(wait, time.Until(*until))
+		}
+	}
+
+	if wait <= 0 {
+		return nil
+	}
+
+	return throttledErr("too many failed attempts, try again later", wait)
+}
+
+// throttledErr is ResourceExhausted with a RetryInfo detail, which the
+// gateway turns into 429 and Retry-After.
+func throttledErr(msg string, wait time.Duration) error {
+	st := status.New(codes.ResourceExhausted, msg)
+	if withInfo, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)}); err == nil {
+		st = withInfo
+	}
+
+	return st.Err()
+}
+
+// recordLoginFailure counts a failed attempt against each key and sets its
+// backoff, publishing a lockout event when a key crosses its threshold.
+func (s *server) recordLoginFailure(ctx context.Context, keys []throttleKey) {
+	for _, k := range keys {
+		var (
+			n      int
+			locked bool
+		)
+		err := s.db.QueryRow(ctx, `
+INSERT INTO login_throttle(kind, value, failures, last_failure_at)
+VALUES($1, $2, 1, now())
+ON CONFLICT (kind, value) DO UPDATE
+SET failures = CASE
+      WHEN login_throttle.last_failure_at < $3 THEN 1
+      ELSE login_throttle.failures + 1
+    END,
+    last_failure_at = now()
+RETURNING failures, locked`,
+			k.kind, k.value, time.Now().Add(-loginFailureTTL),
+		).Scan(&n, &locked)
+		if err != nil {
+			log.Printf("record login failure %s=%s: %v", k.kind, k.value, err)
+			continue
+		}
+
+		lock := n >= k.lockAfter
+		wait := loginBackoff(n)
+		if lock {
+			wait = loginLockout
+		}
+		if wait == 0 {
+			continue
+		}
+
+		until := time.Now().Add(wait)
+		_, err = s.db.Exec(ctx, `
+UPDATE login_throttle
+SET blocked_until = $3,
+    locked = locked OR $4
+WHERE kind = $1 AND value = $2`, k.kind, k.value, until, lock)
+		if err != nil {
+			log.Printf("throttle %s=%s: %v", k.kind, k.value, err)
+			continue
+		}
+
+		if lock && !locked {
+			log.Printf("login lockout: %s=%s failures=%d until=%s", k.kind, k.value, n, until.UTC().Format(time.RFC3339))
+			s.publishLockout(lockoutSubject, lockoutEvent{Kind: k.kind, Value: k.value, Failures: n, Until: &until})
+			s.record(ctx, 0, "auth.lockout", k.kind+":"+k.value,
+				map[string]string{"failures": strconv.Itoa(n), "until": until.UTC().Format(time.RFC3339)})
+		}
+	}
+}
+
+// loginBackoff is the wait after the n-th consecutive failure.
+func loginBackoff(n int) time.Duration {
+	if n <= loginFreeAttempts {
+		return 0
+	}
+
+	shift := n - loginFreeAttempts - 1
+	if shift >= 16 {
+		return loginBackoffMax
+	}
+
+	return min(loginBackoffBase<<shift, loginBackoffMax)
+}
+
+// clearLoginFailures forgets the failures counted against an email, e.g.
+// after a successful login or a password reset.
+func (s *server) clearLoginFailures(ctx context.Context, email, reason string) {
+	s.clearThrottle(ctx, "email", emailKey(email), reason)
+}
+
+// clearThrottle forgets one counter, announcing the unlock if it was locked.
+func (s *server) clearThrottle(ctx context.Context, kind, value, reason string) {
+	var locked bool
+	err := s.db.QueryRow(ctx,
+		`DELETE FROM login_throttle WHERE kind = $1 AND value = $2 RETURNING locked`,
+		kind, value,
+	).Scan(&locked)
+	if err != nil {
+		// Usually pgx.ErrNoRows: there was nothing to clear.
+		return
+	}
+
+	if locked {
+		s.publishLockout(unlockSubject, lockoutEvent{Kind: kind, Value: value, Reason: reason})
+	}
+}
+
+// sweepThrottle announces lockouts that have run out and drops counters
+// that have been quiet for loginFailureTTL, along with mail requests that
+// no longer count against limitMail.
+func (s *server) sweepThrottle(ctx context.Context) error {
+	rows, err := s.db.Query(ctx, `
+UPDATE login_throttle
+SET locked = false
+WHERE locked
+  AND blocked_until <= now()
+RETURNING kind, value, failures`)
+	if err != nil {
+		return err
+	}
+
+	var expired []lockoutEvent
+	for rows.Next() {
+		ev := lockoutEvent{Reason: "expired"}
+		if err := rows.Scan(&ev.Kind, &ev.Value, &ev.Failures); err != nil {
+			rows.Close()
+			return err
+		}
+		expired = append(expired, ev)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, ev := range expired {
+		log.Printf("login lockout expired: %s=%s", ev.Kind, ev.Value)
+		s.publishLockout(unlockSubject, ev)
+	}
+
+	_, err = s.db.Exec(ctx, `
+DELETE FROM login_throttle
+WHERE NOT locked
+  AND last_failure_at < $1`, time.Now().Add(-loginFailureTTL))
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(ctx,
+		`DELETE FROM mail_requests WHERE requested_at < $1`,
+		time.Now().Add(-mailWindow),
+	)
+
+	return err
+}
+
+// publishLockout sends a lockout or unlock event for alerting. Like
+// revocations, failures are logged: the lockout itself is already in Postgres.
+func (s *server) publishLockout(subject string, ev lockoutEvent) {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		log.Printf("marshal lockout event: %v", err)
+		return
+	}
+
+	if err := s.nc.Publish(subject, b); err != nil {
+		log.Printf("publish lockout event: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoginBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{1, 0},
+		{loginFreeAttempts, 0},
+		{loginFreeAttempts + 1, loginBackoffBase},
+		{loginFreeAttempts + 2, 2 * loginBackoffBase},
+		{loginFreeAttempts + 3, 4 * loginBackoffBase},
+		{loginFreeAttempts + 6, 32 * loginBackoffBase},
+		{loginFreeAttempts + 7, loginBackoffMax}, // 64s, capped
+		{loginFreeAttempts + 16, loginBackoffMax},
+		{loginFreeAttempts + 17, loginBackoffMax}, // shift would be 16
+		{1000, loginBackoffMax},                   // no overflow
+	}
+
+	for _, tt := range tests {
+		if got := loginBackoff(tt.failures); got != tt.want {
+			t.Errorf("loginBackoff(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestLoginBackoffGrows(t *testing.T) {
+	prev := time.Duration(0)
+	for n := 1; n <= emailLockoutAfter; n++ {
+		got := loginBackoff(n)
+		if got < prev {
+			t.Fatalf("loginBackoff(%d) = %s, less than loginBackoff(%d) = %s", n, got, n-1, prev)
+		}
+		if got > loginBackoffMax {
+			t.Fatalf("loginBackoff(%d) = %s, over the %s ceiling", n, got, loginBackoffMax)
+		}
+		prev = got
+	}
+}
+
+func TestEmailKey(t *testing.T) {
+	long := strings.Repeat("a", maxEmailLen)
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"a@example.com", "a@example.com"},
+		{"  A@Example.COM\n", "a@example.com"},
+		{"", ""},
+		{long, long},
+		{long + "@example.com", long},
+		{strings.Repeat("a", maxEmailLen-1) + "é", strings.Repeat("a", maxEmailLen-1)}, // no half rune
+	}
+
+	for _, tt := range tests {
+		if got := emailKey(tt.in); got != tt.want {
+			t.Errorf("emailKey(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
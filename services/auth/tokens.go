@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/metadata"
+)
+
+// dbtx is the subset of pgxpool.Pool and pgx.Tx we need, so helpers can run
+// either standalone or inside a caller's transaction.
+type dbtx interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// tokenClaims is the payload of our access tokens. Keep it minimal: JWTs are
+// signed, not encrypted.
+type tokenClaims struct {
+	UID  int64  `json:"uid"`            // our user identifier
+	SID  string `json:"sid,omitempty"`  // refresh token family the token was minted in
+	EV   bool   `json:"ev"`             // email verified; unverified accounts are restricted
+	Role string `json:"role,omitempty"` // rbac.Role; absent on tokens from before roles existed
+
+	// The email, once verified: backends match it against shares by email.
+	Email string `json:"email,omitempty"`
+
+	// Org-scoped tokens only (see IssueOrgToken).
+	Org     int64  `json:"org,omitempty"`      // organization the token acts in
+	OrgRole string `json:"org_role,omitempty"` // caller's role there when minted
+
+	jwt.RegisteredClaims
+}
+
+// startSession records a new session (device) for uid and issues its first
+// tokens. The session ID doubles as the refresh token family.
+func (s *server) startSession(ctx context.Context, q dbtx, uid int64) (*gv1.Token, error) {
+	ua, ip := clientInfo(ctx)
+
+	var family string
+	err := q.QueryRow(ctx,
+		`INSERT INTO sessions(user_id, user_agent, ip) VALUES($1, $2, $3) RETURNING id::text`,
+		uid, ua, ip,
+	).Scan(&family)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, q, uid, family)
+}
+
+// caller authenticates the user an RPC acts on behalf of. The gateway
+// forwards the user's access token as "authorization: Bearer <token>" metadata.
+func (s *server) caller(ctx context.Context) (*tokenClaims, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	vals := md.Get("authorization")
+	if len(vals) == 0 || !strings.HasPrefix(vals[0], "Bearer ") {
+		return nil, grpcErr("missing token")
+	}
+
+	c, err := s.parseAccessToken(strings.TrimPrefix(vals[0], "Bearer "))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.checkRevoked(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// issueTokens mints a short-lived access token plus a single-use refresh token.
+// Every login starts a new refresh "family"; Refresh rotates within it, so a
+// replayed token can take down the whole chain it came from.
+func (s *server) issueTokens(ctx context.Context, q dbtx, uid int64, family string) (*gv1.Token, error) {
+	// Claims reflect the account as it is now, so a refresh picks up changes
+	// such as a just-verified email.
+	var (
+		email     string
+		verified  bool
+		role      string
+		suspended bool
+		deleted   bool
+	)
+	err := q.QueryRow(ctx, `
+SELECT email, email_verified_at IS NOT NULL, role, suspended_at IS NOT NULL,
+       delete_after IS NOT NULL AND delete_after <= now()
+FROM users
+WHERE id = $1`, uid,
+	).Scan(&email, &verified, &role, &suspended, &deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every way of getting tokens (login, MFA, SSO, refresh) ends up here.
+	// Accounts past their deletion date are as good as gone, as in Login.
+	if deleted {
+		return nil, grpcErr("invalid credentials")
+	}
+	if suspended {
+		return nil, errSuspended
+	}
+
+	claims := tokenClaims{UID: uid, SID: family, EV: verified, Role: role}
+	if verified {
+		claims.Email = email
+	}
+
+	signed, exp, err := s.signAccessToken(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, err := randToken()
+	if err != nil {
+		return nil, err
+	}
+	refreshExp := time.Now().Add(s.refreshTTL)
+
+	// Only the hash is stored; a DB leak must not hand out usable refresh tokens.
+	_, err = q.Exec(ctx, `
+INSERT INTO refresh_tokens(user_id, family_id, token_hash, expires_at)
+VALUES($1, $2, $3, $4)`,
+		uid, family, hashToken(refresh), refreshExp,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, uid, "auth.token_issued", userTarget(uid), map[string]string{"session": family})
+
+	return &gv1.Token{
+		AccessToken:      signed,
+		ExpiresAt:        exp.UTC().Format(time.RFC3339),
+		RefreshToken:     refresh,
+		RefreshExpiresAt: refreshExp.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// signAccessToken stamps c with a fresh jti, issue time and expiry, and signs
+// it with the active key.
+func (s *server) signAccessToken(c tokenClaims) (string, time.Time, error) {
+	now := time.Now()
+	exp := now.Add(s.accessTTL)
+
+	jti, err := randToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	c.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        jti,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(exp),
+	}
+
+	kid, key := s.keys.signer()
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, c)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, exp, nil
+}
+
+// Refresh exchanges a refresh token for a new access/refresh pair.
+// Refresh tokens are single-use: the presented token is marked used and a new
+// one is issued in the same family. Presenting an already-used token is
+// treated as theft and revokes every token in the family.
+func (s *server) Refresh(ctx context.Context, in *gv1.RefreshRequest) (*gv1.Token, error) {
+	if in.RefreshToken == "" {
+		return nil, grpcErr("invalid refresh token")
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		id      int64
+		uid     int64
+		family  string
+		expires time.Time
+		used    *time.Time
+		revoked *time.Time
+	)
+
+	// Lock the row so two concurrent refreshes of the same token can't both win.
+	err = tx.QueryRow(ctx, `
+SELECT id, user_id, family_id::text, expires_at, used_at, revoked_at
+FROM refresh_tokens
+WHERE token_hash = $1
+FOR UPDATE`,
+		hashToken(in.RefreshToken),
+	).Scan(&id, &uid, &family, &expires, &used, &revoked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, grpcErr("invalid refresh token")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if used != nil || revoked != nil {
+		if used != nil {
+			log.Printf("refresh token reuse detected: uid=%d family=%s", uid, family)
+			s.record(ctx, uid, "auth.refresh_reuse", userTarget(uid), map[string]string{"session": family})
+		}
+
+		if _, err := tx.Exec(ctx, `
+UPDATE refresh_tokens
+SET revoked_at = now()
+WHERE family_id = $1
+  AND revoked_at IS NULL`, family); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+
+		return nil, grpcErr("invalid refresh token")
+	}
+
+	if time.Now().After(expires) {
+		return nil, grpcErr("refresh token expired")
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE refresh_tokens SET used_at = now() WHERE id = $1`, id); err != nil {
+		return nil, err
+	}
+
+	tok, err := s.issueTokens(ctx, tx, uid, family)
+	if err != nil {
+		return nil, err
+	}
+
+	// Clients refresh every ACCESS_TOKEN_TTL while in use, which makes this
+	// a good enough "last seen".
+	if _, err := tx.Exec(ctx, `UPDATE sessions SET last_seen_at = now() WHERE id = $1`, family); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return tok, nil
+}
+
+// randToken returns a URL-safe opaque token with 256 bits of entropy.
+func randToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken is the at-rest form of opaque tokens. A plain SHA-256 is enough
+// here: the input is high-entropy random, not a user-chosen password.
+func hashToken(t string) string {
+	sum := sha256.Sum256([]byte(t))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"godrive/internal/audit"
+	"godrive/internal/identity"
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pgUniqueViolation is Postgres' unique_violation error code.
+const pgUniqueViolation = "23505"
+
+// maxNameLen is the longest file or folder name we accept, in bytes.
+const maxNameLen = 255
+
+// itemColumns are the files columns scanItem reads, in order.
+const itemColumns = `id, owner_id, name, mime, size_bytes, created_at,
+	COALESCE(version_id, ''), COALESCE(parent_id, 0), is_folder`
+
+// dbtx is what both a pool and a transaction can do.
+type dbtx interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// scanItem reads one row of itemColumns, then any extra columns into extra.
+func scanItem(row pgx.Row, extra ...any) (*gv1.FileItem, error) {
+	var (
+		f       gv1.FileItem
+		created time.Time
+	)
+	dest := []any{&f.Id, &f.OwnerId, &f.Name, &f.Mime, &f.SizeBytes, &created,
+		&f.VersionId, &f.ParentId, &f.IsFolder}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	f.CreatedAt = created.UTC().Format(time.RFC3339)
+
+	return &f, nil
+}
+
+// parentArg is a folder ID as stored in files.parent_id: NULL for the top level.
+func parentArg(folderID int64) *int64 {
+	if folderID == 0 {
+		return nil
+	}
+
+	return &folderID
+}
+
+// cleanName trims a file or folder name and rejects ones that can't be
+// told apart in a path.
+func cleanName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "" || name == "." || name == "..":
+		return "", status.Error(codes.InvalidArgument, "invalid name")
+	case strings.ContainsAny(name, "/\x00"):
+		return "", status.Error(codes.InvalidArgument, `name may not contain "/"`)
+	case len(name) > maxNameLen:
+		return "", status.Errorf(codes.InvalidArgument, "name is longer than %d bytes", maxNameLen)
+	}
+
+	return name, nil
+}
+
+// numbered is the n-th alternative for a taken name: "report (2).pdf".
+func numbered(name string, n int) string {
+	if n < 2 {
+		return name
+	}
+
+	ext := path.Ext(name)
+	if ext == name {
+		ext = "" // ".bashrc"
+	}
+
+	return fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), n, ext)
+}
+
+// freeName returns name, or its first numbered alternative that isn't
+// taken in the folder.
+func freeName(ctx context.Context, q dbtx, owner, parent int64, name string) (string, error) {
+	for n := 1; n <= maxNameTries; n++ {
+		var taken bool
+		err := q.QueryRow(ctx, `
+SELECT EXISTS (
+  SELECT 1 FROM files
+  WHERE owner_id = $1
+    AND parent_id IS NOT DISTINCT FROM $2
+    AND lower(name) = lower($3)
+    AND deleted_at IS NULL
+)`, owner, parentArg(parent), numbered(name, n),
+		).Scan(&taken)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return numbered(name, n), nil
+		}
+	}
+
+	return "", status.Errorf(codes.AlreadyExists, "no free name for %q", name)
+}
+
+// checkFolder makes sure folderID is one of owner's folders and not deleted.
+// 0, the top level, always is.
+func checkFolder(ctx context.Context, q dbtx, owner, folderID int64) error {
+	if folderID == 0 {
+		return nil
+	}
+
+	var isFolder bool
+	err := q.QueryRow(ctx,
+		`SELECT is_folder FROM files WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL`,
+		folderID, owner,
+	).Scan(&isFolder)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return status.Error(codes.NotFound, "folder not found")
+	}
+	if err != nil {
+		return err
+	}
+	if !isFolder {
+		return status.Error(codes.InvalidArgument, "not a folder")
+	}
+
+	return nil
+}
+
+// checkNotInside refuses to move folder into target when target is the
+// folder itself or somewhere below it.
+func checkNotInside(ctx context.Context, q dbtx, target, folder int64) error {
+	var inside bool
+	err := q.QueryRow(ctx, `
+WITH RECURSIVE up AS (
+  SELECT id, parent_id, 0 AS depth FROM files WHERE id = $1
+  UNION ALL
+  SELECT f.id, f.parent_id, up.depth + 1
+  FROM files f
+  JOIN up ON f.id = up.parent_id
+  WHERE up.depth < $3
+)
+SELECT EXISTS (SELECT 1 FROM up WHERE id = $2)`, target, folder, maxDepth,
+	).Scan(&inside)
+	if err != nil {
+		return err
+	}
+	if inside {
+		return status.Error(codes.FailedPrecondition, "can't move a folder into itself")
+	}
+
+	return nil
+}
+
+// CreateFolder makes a folder inside another one, or at the top level.
+// Inside a folder shared with the caller, it belongs to that folder's owner.
+func (s *server) CreateFolder(ctx context.Context, in *gv1.CreateFolderRequest) (*gv1.FileItem, error) {
+	name, err := cleanName(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := folderOwner(ctx, s.db, in.OwnerId, in.ParentId, canEdit)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFolder(ctx, s.db, owner, in.ParentId); err != nil {
+		return nil, err
+	}
+
+	f, err := scanItem(s.db.QueryRow(ctx, `
+INSERT INTO files(owner_id, parent_id, name, mime, size_bytes, object_key, is_folder)
+VALUES($1, $2, $3, '', 0, '', true)
+RETURNING `+itemColumns,
+		owner, parentArg(in.ParentId), name,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "%q already exists in this folder", name)
+		}
+		return nil, err
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		ActorID: in.OwnerId,
+		Action:  "files.folder_created",
+		Target:  fmt.Sprintf("file:%d", f.Id),
+		IP:      audit.ClientIP(ctx),
+	})
+
+	return f, nil
+}
+
+// ListChildren pages through a folder's contents: the caller's own, or one
+// shared with them.
+func (s *server) ListChildren(ctx context.Context, in *gv1.ListChildrenRequest) (*gv1.ListFilesResponse, error) {
+	owner, err := folderOwner(ctx, s.db, in.OwnerId, in.FolderId, canView)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFolder(ctx, s.db, owner, in.FolderId); err != nil {
+		return nil, err
+	}
+
+	page, pageSize, offset := paging(in.Page, in.PageSize)
+
+	rows, err := s.db.Query(ctx, `
+SELECT `+itemColumns+`
+FROM files
+WHERE owner_id = $1
+  AND parent_id IS NOT DISTINCT FROM $2
+  AND deleted_at IS NULL
+ORDER BY is_folder DESC, lower(name), id
+LIMIT $3 OFFSET $4`, owner, parentArg(in.FolderId), pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*gv1.FileItem
+	for rows.Next() {
+		f, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	nextPage := int32(0)
+	if int32(len(items)) == pageSize {
+		nextPage = page + 1
+	}
+
+	return &gv1.ListFilesResponse{Files: items, NextPage: nextPage}, nil
+}
+
+// maxDepth bounds how far we walk up a chain of folders, in case of a cycle.
+const maxDepth = 256
+
+// GetPath returns a file or folder with its ancestors, top level first.
+// For a shared item, the path starts at the topmost folder the caller can see.
+func (s *server) GetPath(ctx context.Context, in *gv1.FilePathRequest) (*gv1.FilePath, error) {
+	owner, err := authorize(ctx, s.db, in.OwnerId, in.FileId, canView)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `
+WITH RECURSIVE up AS (
+  SELECT f.*, 0 AS depth
+  FROM files f
+  WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+  UNION ALL
+  SELECT f.*, up.depth + 1
+  FROM files f
+  JOIN up ON f.id = up.parent_id
+  WHERE up.depth < $3
+)
+SELECT `+itemColumns+`
+FROM up
+ORDER BY depth DESC`, in.FileId, owner, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := &gv1.FilePath{}
+	for rows.Next() {
+		f, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		out.Items = append(out.Items, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(out.Items) == 0 {
+		return nil, status.Error(codes.NotFound, "file not found")
+	}
+
+	// Grants are inherited, so once one folder is visible all below it are.
+	for owner != in.OwnerId && len(out.Items) > 1 {
+		_, a, err := accessTo(ctx, s.db, in.OwnerId, identity.VerifiedEmail(ctx), out.Items[0].Id)
+		if err != nil {
+			return nil, err
+		}
+		if a != noAccess {
+			break
+		}
+		out.Items = out.Items[1:]
+	}
+
+	return out, nil
+}
+
+// ResolvePath walks a "/"-separated path from the caller's own top level.
+// Names match ignoring case, as they are unique that way. Shared items
+// aren't on any of the caller's paths.
+func (s *server) ResolvePath(ctx context.Context, in *gv1.ResolvePathRequest) (*gv1.FileItem, error) {
+	var (
+		f      *gv1.FileItem
+		parent int64
+	)
+	for _, name := range strings.Split(in.Path, "/") {
+		if name == "" {
+			continue
+		}
+		if f != nil && !f.IsFolder {
+			return nil, status.Error(codes.NotFound, "path not found")
+		}
+
+		var err error
+		f, err = scanItem(s.db.QueryRow(ctx, `
+SELECT `+itemColumns+`
+FROM files
+WHERE owner_id = $1
+  AND parent_id IS NOT DISTINCT FROM $2
+  AND lower(name) = lower($3)
+  AND deleted_at IS NULL`, in.OwnerId, parentArg(parent), name))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "path not found")
+		}
+		if err != nil {
+			return nil, err
+		}
+		parent = f.Id
+	}
+
+	if f == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty path")
+	}
+
+	return f, nil
+}
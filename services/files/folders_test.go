@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCleanName(t *testing.T) {
+	long := strings.Repeat("a", maxNameLen)
+
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"report.pdf", "report.pdf", false},
+		{"  report.pdf \n", "report.pdf", false},
+		{".bashrc", ".bashrc", false},
+		{"...", "...", false},
+		{"a\\b", "a\\b", false},
+		{long, long, false},
+		{"", "", true},
+		{"   ", "", true},
+		{".", "", true},
+		{"..", "", true},
+		{" .. ", "", true},
+		{"a/b", "", true},
+		{"/", "", true},
+		{"a\x00b", "", true},
+		{long + "a", "", true},
+		{strings.Repeat("é", maxNameLen/2+1), "", true}, // bytes, not runes
+	}
+
+	for _, tt := range tests {
+		got, err := cleanName(tt.in)
+		if tt.wantErr {
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("cleanName(%q) error = %v, want InvalidArgument", tt.in, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("cleanName(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestNumbered(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+		want string
+	}{
+		{"report.pdf", 0, "report.pdf"},
+		{"report.pdf", 1, "report.pdf"},
+		{"report.pdf", 2, "report (2).pdf"},
+		{"report.pdf", 10, "report (10).pdf"},
+		{"archive.tar.gz", 2, "archive.tar (2).gz"},
+		{"Photos", 3, "Photos (3)"},
+		{".bashrc", 2, ".bashrc (2)"},
+		{"notes.", 2, "notes (2)."},
+		{"report (2).pdf", 2, "report (2) (2).pdf"},
+	}
+
+	for _, tt := range tests {
+		if got := numbered(tt.name, tt.n); got != tt.want {
+			t.Errorf("numbered(%q, %d) = %q, want %q", tt.name, tt.n, got, tt.want)
+		}
+	}
+}
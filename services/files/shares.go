@@ -0,0 +1,321 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"godrive/internal/audit"
+	"godrive/internal/identity"
+	gv1 "godrive/proto/godrive/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// access is what a user may do with a file or folder, least first.
+type access int
+
+const (
+	noAccess access = iota
+	canView
+	canComment
+	canEdit
+	isOwner
+)
+
+// shareRoles are the roles a share can grant. Commenters can't do more than
+// viewers yet; there are no comments to write.
+var shareRoles = map[string]access{
+	"viewer":    canView,
+	"commenter": canComment,
+	"editor":    canEdit,
+}
+
+// roleNames are shareRoles the other way round, and "owner".
+var roleNames = map[access]string{
+	canView:    "viewer",
+	canComment: "commenter",
+	canEdit:    "editor",
+	isOwner:    "owner",
+}
+
+// roleRank is a shares row's role (aliased s) as an access value.
+const roleRank = `CASE s.role WHEN 'viewer' THEN 1 WHEN 'commenter' THEN 2 WHEN 'editor' THEN 3 END`
+
+// grantedTo matches the shares rows (aliased s) that apply to a user: those
+// for their ID, and those for their verified email. The email comes from the
+// signed identity (see identity.VerifiedEmail); "" matches no email grants.
+func grantedTo(idParam, emailParam string) string {
+	return `(s.grantee_id = ` + idParam + ` OR s.grantee_email = lower(` + emailParam + `))`
+}
+
+// accessTo returns who owns a file or folder that isn't in the trash and
+// what uid, with the given verified email, may do with it, counting grants
+// on the folders it is in. The owner is 0 if there is no such file.
+func accessTo(ctx context.Context, q dbtx, uid int64, email string, fileID int64) (int64, access, error) {
+	var (
+		owner *int64
+		rank  int
+	)
+	err := q.QueryRow(ctx, `
+WITH RECURSIVE up AS (
+  SELECT id, parent_id, owner_id, 0 AS depth
+  FROM files
+  WHERE id = $1 AND deleted_at IS NULL
+  UNION ALL
+  SELECT f.id, f.parent_id, f.owner_id, up.depth + 1
+  FROM files f
+  JOIN up ON f.id = up.parent_id
+  WHERE up.depth < $3
+)
+SELECT
+  (SELECT owner_id FROM up WHERE depth = 0),
+  COALESCE((
+    SELECT max(`+roleRank+`)
+    FROM up
+    JOIN shares s ON s.file_id = up.id
+    WHERE `+grantedTo("$2", "$4")+`
+  ), 0)`, fileID, uid, maxDepth, email,
+	).Scan(&owner, &rank)
+	if err != nil || owner == nil {
+		return 0, noAccess, err
+	}
+	if *owner == uid {
+		return uid, isOwner, nil
+	}
+
+	return *owner, access(rank), nil
+}
+
+// authorize checks that uid, the signed-in user, may act on a file or folder
+// with at least want, and returns its owner. Files they can't see at all are
+// NotFound, as if they didn't exist.
+func authorize(ctx context.Context, q dbtx, uid, fileID int64, want access) (int64, error) {
+	return authorizeAs(ctx, q, uid, identity.VerifiedEmail(ctx), fileID, want)
+}
+
+// authorizeAs is authorize for a user who isn't signed in to the call, with
+// their verified email as it was when they were.
+func authorizeAs(ctx context.Context, q dbtx, uid int64, email string, fileID int64, want access) (int64, error) {
+	owner, a, err := accessTo(ctx, q, uid, email, fileID)
+	if err != nil {
+		return 0, err
+	}
+	if a == noAccess {
+		return 0, status.Error(codes.NotFound, "file not found")
+	}
+	if a < want {
+		return 0, status.Errorf(codes.PermissionDenied, "this needs %s access", roleNames[want])
+	}
+
+	return owner, nil
+}
+
+// ShareFile grants someone a role on a file or folder, or changes the role
+// they have. Only the owner may, and only once their email is verified.
+func (s *server) ShareFile(ctx context.Context, in *gv1.ShareRequest) (*gv1.Share, error) {
+	if identity.VerifiedEmail(ctx) == "" {
+		return nil, status.Error(codes.PermissionDenied, "verify your email to share")
+	}
+	if _, err := authorize(ctx, s.db, in.OwnerId, in.FileId, isOwner); err != nil {
+		return nil, err
+	}
+	if _, ok := shareRoles[in.Role]; !ok {
+		return nil, status.Error(codes.InvalidArgument, `role must be "viewer", "commenter" or "editor"`)
+	}
+
+	var (
+		userID *int64
+		email  *string
+		// the partial unique index the grant may collide with
+		conflict = `(file_id, grantee_id) WHERE grantee_id IS NOT NULL`
+	)
+	switch addr := strings.ToLower(strings.TrimSpace(in.Email)); {
+	case in.UserId != 0 && addr != "":
+		return nil, status.Error(codes.InvalidArgument, "share with a user ID or an email, not both")
+	case in.UserId == in.OwnerId:
+		return nil, status.Error(codes.InvalidArgument, "you own this already")
+	case in.UserId != 0:
+		// Users are auth's; ask it rather than guess at an ID that may be
+		// taken by someone else later.
+		if _, err := s.auth.LookupUser(ctx, &gv1.UserLookup{UserId: in.UserId}); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil, status.Error(codes.NotFound, "user not found")
+			}
+			return nil, err
+		}
+		userID = &in.UserId
+	case strings.Contains(addr, "@"):
+		email = &addr
+		conflict = `(file_id, grantee_email) WHERE grantee_email IS NOT NULL`
+	default:
+		return nil, status.Error(codes.InvalidArgument, "share with a user ID or an email")
+	}
+
+	var (
+		sh      gv1.Share
+		uid     *int64
+		addr    *string
+		created time.Time
+	)
+	err := s.db.QueryRow(ctx, `
+INSERT INTO shares(file_id, grantee_id, grantee_email, role, created_by)
+VALUES($1, $2, $3, $4, $5)
+ON CONFLICT `+conflict+` DO UPDATE SET role = EXCLUDED.role
+RETURNING id, file_id, grantee_id, grantee_email, role, created_at`,
+		in.FileId, userID, email, in.Role, in.OwnerId,
+	).Scan(&sh.Id, &sh.FileId, &uid, &addr, &sh.Role, &created)
+	if err != nil {
+		return nil, err
+	}
+	fillShare(&sh, uid, addr, created)
+
+	s.audit.Record(ctx, audit.Event{
+		ActorID: in.OwnerId,
+		Action:  "files.shared",
+		Target:  fmt.Sprintf("file:%d", in.FileId),
+		IP:      audit.ClientIP(ctx),
+		Details: map[string]string{"share_id": fmt.Sprint(sh.Id), "role": sh.Role},
+	})
+
+	return &sh, nil
+}
+
+// fillShare sets a Share's grantee and time from their columns.
+func fillShare(sh *gv1.Share, uid *int64, email *string, created time.Time) {
+	if uid != nil {
+		sh.UserId = *uid
+	}
+	if email != nil {
+		sh.Email = *email
+	}
+	sh.CreatedAt = created.UTC().Format(time.RFC3339)
+}
+
+// ListShares lists who has been granted access to a file, directly or
+// through a folder it is in. Owners and editors may see it.
+func (s *server) ListShares(ctx context.Context, in *gv1.SharesRequest) (*gv1.Shares, error) {
+	if _, err := authorize(ctx, s.db, in.OwnerId, in.FileId, canEdit); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `
+WITH RECURSIVE up AS (
+  SELECT id, parent_id, 0 AS depth FROM files WHERE id = $1
+  UNION ALL
+  SELECT f.id, f.parent_id, up.depth + 1
+  FROM files f
+  JOIN up ON f.id = up.parent_id
+  WHERE up.depth < $2
+)
+SELECT s.id, s.file_id, s.grantee_id, s.grantee_email, s.role, s.created_at
+FROM shares s
+JOIN up ON up.id = s.file_id
+ORDER BY up.depth, s.created_at`, in.FileId, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := &gv1.Shares{}
+	for rows.Next() {
+		var (
+			sh      gv1.Share
+			uid     *int64
+			email   *string
+			created time.Time
+		)
+		if err := rows.Scan(&sh.Id, &sh.FileId, &uid, &email, &sh.Role, &created); err != nil {
+			return nil, err
+		}
+		fillShare(&sh, uid, email, created)
+		out.Shares = append(out.Shares, &sh)
+	}
+
+	return out, rows.Err()
+}
+
+// Unshare takes back a grant made on a file or folder. Only the owner may.
+func (s *server) Unshare(ctx context.Context, in *gv1.UnshareRequest) (*gv1.Empty, error) {
+	if _, err := authorize(ctx, s.db, in.OwnerId, in.FileId, isOwner); err != nil {
+		return nil, err
+	}
+
+	ct, err := s.db.Exec(ctx, `DELETE FROM shares WHERE id = $1 AND file_id = $2`, in.ShareId, in.FileId)
+	if err != nil {
+		return nil, err
+	}
+	if ct.RowsAffected() == 0 {
+		return nil, status.Error(codes.NotFound, "share not found")
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		ActorID: in.OwnerId,
+		Action:  "files.unshared",
+		Target:  fmt.Sprintf("file:%d", in.FileId),
+		IP:      audit.ClientIP(ctx),
+		Details: map[string]string{"share_id": fmt.Sprint(in.ShareId)},
+	})
+
+	return &gv1.Empty{}, nil
+}
+
+// ListSharedWithMe pages through the files and folders others shared with
+// the caller, most recently shared first. What is inside a shared folder is
+// reached through ListChildren.
+func (s *server) ListSharedWithMe(ctx context.Context, in *gv1.SharedWithMeRequest) (*gv1.SharedWithMeResponse, error) {
+	page, pageSize, offset := paging(in.Page, in.PageSize)
+
+	rows, err := s.db.Query(ctx, `
+SELECT `+itemColumns+`, g.rank
+FROM files
+JOIN (
+  SELECT s.file_id, max(`+roleRank+`) AS rank, max(s.created_at) AS shared_at
+  FROM shares s
+  WHERE `+grantedTo("$1", "$4")+`
+  GROUP BY s.file_id
+) g ON g.file_id = files.id
+WHERE owner_id <> $1
+  AND deleted_at IS NULL
+ORDER BY g.shared_at DESC, id
+LIMIT $2 OFFSET $3`, in.OwnerId, pageSize, offset, identity.VerifiedEmail(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*gv1.SharedItem
+	for rows.Next() {
+		var rank int
+		f, err := scanItem(rows, &rank)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &gv1.SharedItem{File: f, Role: roleNames[access(rank)]})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	nextPage := int32(0)
+	if int32(len(items)) == pageSize {
+		nextPage = page + 1
+	}
+
+	return &gv1.SharedWithMeResponse{Items: items, NextPage: nextPage}, nil
+}
+
+// errNotOwner is returned when only the owner of a shared item may do something.
+var errNotOwner = status.Error(codes.PermissionDenied, "only the owner can do that")
+
+// folderOwner returns the owner of a folder uid wants to use with at least
+// want access. The top level (0) is uid's own.
+func folderOwner(ctx context.Context, q dbtx, uid, folderID int64, want access) (int64, error) {
+	if folderID == 0 {
+		return uid, nil
+	}
+
+	return authorize(ctx, q, uid, folderID, want)
+}
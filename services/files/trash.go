@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"godrive/internal/audit"
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// trashCutoff is the deleted_at at and before which an item is due for
+// purging. Those are gone as far as the user is concerned.
+func (s *server) trashCutoff() time.Time {
+	return time.Now().Add(-s.gracePeriod)
+}
+
+// ListTrash pages through the owner's deleted items, most recent first.
+// Deleting a folder stamps it and everything in it with the same deleted_at;
+// those contents are left out, as they come back with the folder. Things
+// deleted on their own before it are listed.
+func (s *server) ListTrash(ctx context.Context, in *gv1.ListTrashRequest) (*gv1.ListTrashResponse, error) {
+	page, pageSize, offset := paging(in.Page, in.PageSize)
+
+	rows, err := s.db.Query(ctx, `
+SELECT `+itemColumns+`, deleted_at
+FROM files f
+WHERE owner_id = $1
+  AND deleted_at > $2
+  AND NOT EXISTS (
+    SELECT 1 FROM files p
+    WHERE p.id = f.parent_id
+      AND p.deleted_at = f.deleted_at
+  )
+ORDER BY deleted_at DESC, id
+LIMIT $3 OFFSET $4`, in.OwnerId, s.trashCutoff(), pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*gv1.TrashedItem
+	for rows.Next() {
+		var deleted time.Time
+		f, err := scanItem(rows, &deleted)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &gv1.TrashedItem{
+			File:      f,
+			DeletedAt: deleted.UTC().Format(time.RFC3339),
+			PurgeAt:   deleted.Add(s.gracePeriod).UTC().Format(time.RFC3339),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	nextPage := int32(0)
+	if int32(len(items)) == pageSize {
+		nextPage = page + 1
+	}
+
+	return &gv1.ListTrashResponse{Items: items, NextPage: nextPage}, nil
+}
+
+// Restore takes an item out of the trash, with whatever was deleted along
+// with it. It goes back to its folder, or to the top level if that folder is
+// in the trash itself; a name taken there in the meantime gets a number.
+func (s *server) Restore(ctx context.Context, in *gv1.TrashRequest) (*gv1.FileItem, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		name   string
+		parent int64
+	)
+	err = tx.QueryRow(ctx, `
+SELECT name, COALESCE(parent_id, 0)
+FROM files
+WHERE id = $1
+  AND owner_id = $2
+  AND deleted_at > $3
+FOR UPDATE`, in.FileId, in.OwnerId, s.trashCutoff(),
+	).Scan(&name, &parent)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "not in the trash")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkFolder(ctx, tx, in.OwnerId, parent); err != nil {
+		if _, ok := status.FromError(err); !ok {
+			return nil, err
+		}
+		parent = 0
+	}
+	if name, err = freeName(ctx, tx, in.OwnerId, parent, name); err != nil {
+		return nil, err
+	}
+
+	ct, err := tx.Exec(ctx, `
+WITH RECURSIVE tree AS (
+  SELECT id, deleted_at FROM files
+  WHERE id = $1
+  UNION ALL
+  SELECT f.id, f.deleted_at FROM files f
+  JOIN tree ON f.parent_id = tree.id
+  WHERE f.deleted_at = tree.deleted_at
+)
+UPDATE files
+SET deleted_at = NULL
+WHERE id IN (SELECT id FROM tree)
+  AND id <> $1`, in.FileId)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := scanItem(tx.QueryRow(ctx, `
+UPDATE files
+SET deleted_at = NULL, name = $2, parent_id = $3
+WHERE id = $1
+RETURNING `+itemColumns, in.FileId, name, parentArg(parent)))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "%q already exists in that folder", name)
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		ActorID: in.OwnerId,
+		Action:  "files.restored",
+		Target:  fmt.Sprintf("file:%d", in.FileId),
+		IP:      audit.ClientIP(ctx),
+		Details: map[string]string{"items": fmt.Sprint(ct.RowsAffected() + 1)},
+	})
+
+	return f, nil
+}
+
+// PurgeNow makes an item in the trash, or the whole trash, due for purging
+// instead of waiting out the grace period. Moving deleted_at back to the
+// epoch puts it past any grace period, so the janitor deletes the objects
+// on its next run. Contents of a folder go with it, even those deleted
+// before it.
+func (s *server) PurgeNow(ctx context.Context, in *gv1.TrashRequest) (*gv1.Empty, error) {
+	ct, err := s.db.Exec(ctx, `
+WITH RECURSIVE tree AS (
+  SELECT id FROM files
+  WHERE owner_id = $1
+    AND ($2::bigint = 0 OR id = $2)
+    AND deleted_at > $3
+  UNION
+  SELECT f.id FROM files f
+  JOIN tree ON f.parent_id = tree.id
+  WHERE f.deleted_at IS NOT NULL
+)
+UPDATE files
+SET deleted_at = 'epoch'
+WHERE id IN (SELECT id FROM tree)`, in.OwnerId, in.FileId, s.trashCutoff())
+	if err != nil {
+		return nil, err
+	}
+	if ct.RowsAffected() == 0 && in.FileId != 0 {
+		return nil, status.Error(codes.NotFound, "not in the trash")
+	}
+
+	target := fmt.Sprintf("file:%d", in.FileId)
+	if in.FileId == 0 {
+		target = fmt.Sprintf("user:%d", in.OwnerId)
+	}
+	if ct.RowsAffected() > 0 {
+		s.audit.Record(ctx, audit.Event{
+			ActorID: in.OwnerId,
+			Action:  "files.purge_requested",
+			Target:  target,
+			IP:      audit.ClientIP(ctx),
+			Details: map[string]string{"items": fmt.Sprint(ct.RowsAffected())},
+		})
+	}
+
+	return &gv1.Empty{}, nil
+}
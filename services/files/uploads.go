@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"godrive/internal/identity"
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxNameTries is how many numbered names ConfirmUpload tries when the
+// uploaded name is taken in its folder.
+const maxNameTries = 100
+
+// PrepareUpload records where an upload is going and returns the object key
+// to presign. The target folder, or file for a new version, is checked now,
+// while the user can still be told; the row is picked up by ConfirmUpload
+// when the object arrives. The key is always under the uploader's prefix,
+// even when the file will be someone else's, in a folder they shared.
+func (s *server) PrepareUpload(ctx context.Context, in *gv1.PrepareUploadRequest) (*gv1.PrepareUploadResponse, error) {
+	var (
+		name   string
+		folder = in.FolderId
+		owner  int64
+		fileID *int64 // NULL unless this is a new version
+		err    error
+	)
+	if in.FileId != 0 {
+		fileID = &in.FileId
+		if owner, err = authorize(ctx, s.db, in.OwnerId, in.FileId, canEdit); err == nil {
+			err = s.db.QueryRow(ctx, `
+SELECT name, COALESCE(parent_id, 0)
+FROM files
+WHERE id = $1
+  AND NOT is_folder`, in.FileId,
+			).Scan(&name, &folder)
+			if errors.Is(err, pgx.ErrNoRows) {
+				err = status.Error(codes.InvalidArgument, "not a file")
+			}
+		}
+	} else if name, err = cleanName(in.Filename); err == nil {
+		if owner, err = folderOwner(ctx, s.db, in.OwnerId, folder, canEdit); err == nil {
+			err = checkFolder(ctx, s.db, owner, folder)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := newObjectKey(in.OwnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileOwner *int64 // NULL for the uploader's own files
+	if owner != in.OwnerId {
+		fileOwner = &owner
+	}
+
+	// ConfirmUpload checks the grant again without a signed-in user, so it
+	// needs the email that may have been granted.
+	var email *string
+	if e := identity.VerifiedEmail(ctx); e != "" && fileOwner != nil {
+		email = &e
+	}
+
+	_, err = s.db.Exec(ctx, `
+INSERT INTO uploads(object_key, owner_id, parent_id, name, mime, file_id, file_owner_id, uploader_email)
+VALUES($1, $2, $3, $4, $5, $6, $7, $8)`,
+		key, in.OwnerId, parentArg(folder), name, in.Mime, fileID, fileOwner, email,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gv1.PrepareUploadResponse{ObjectKey: key}, nil
+}
+
+// newObjectKey returns a fresh key "user/<owner>/<random id>". Keys say
+// nothing about the file's name or folder, so renames and moves never touch
+// the object; the owner prefix lets storage check who may presign it.
+func newObjectKey(owner int64) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("user/%d/%s", owner, hex.EncodeToString(b)), nil
+}
+
+// pendingUpload is where an arriving object goes, from its uploads row.
+type pendingUpload struct {
+	owner  int64 // who the file will belong to
+	parent int64
+	name   string
+	mime   string
+	fileID int64 // for a new version of this file; 0 if by name
+}
+
+// takeUpload claims the uploads row for key, which has the file's name.
+// Objects uploaded without one (before folders existed) go to the top level
+// under the name ingest found in their key.
+func takeUpload(ctx context.Context, tx pgx.Tx, uploader int64, key string, fallback pendingUpload) (pendingUpload, error) {
+	var (
+		up                    pendingUpload
+		owner, parent, fileID *int64
+		email                 *string
+	)
+	err := tx.QueryRow(ctx, `
+DELETE FROM uploads
+WHERE object_key = $1
+  AND owner_id = $2
+RETURNING file_owner_id, parent_id, name, mime, file_id, uploader_email`, key, uploader,
+	).Scan(&owner, &parent, &up.name, &up.mime, &fileID, &email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fallback, nil
+	}
+	if err != nil {
+		return up, err
+	}
+
+	up.owner = uploader
+	if owner != nil {
+		up.owner = *owner
+	}
+	if parent != nil {
+		up.parent = *parent
+	}
+	if fileID != nil {
+		up.fileID = *fileID
+	}
+	if up.mime == "" {
+		up.mime = fallback.mime
+	}
+
+	// The folder may have been deleted, or stopped being shared with the
+	// uploader, while the upload was in flight. Then it goes to the
+	// uploader's own top level.
+	err = checkFolder(ctx, tx, up.owner, up.parent)
+	if err == nil && up.owner != uploader {
+		target := up.parent
+		if up.fileID != 0 {
+			target = up.fileID
+		}
+		var granted string
+		if email != nil {
+			granted = *email
+		}
+		_, err = authorizeAs(ctx, tx, uploader, granted, target, canEdit)
+	}
+	if err != nil {
+		if _, ok := status.FromError(err); !ok {
+			return up, err
+		}
+		up.owner, up.parent, up.fileID = uploader, 0, 0
+	}
+
+	return up, nil
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"godrive/internal/audit"
+	"godrive/internal/identity"
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// versionOf finds the file an upload is a new version of, and locks it: the
+// one it was prepared for, else a file with its name in its folder. It is 0
+// when the upload is a new file.
+func versionOf(ctx context.Context, tx pgx.Tx, up pendingUpload) (int64, error) {
+	var id int64
+	if up.fileID != 0 {
+		err := tx.QueryRow(ctx, `
+SELECT id FROM files
+WHERE id = $1
+  AND owner_id = $2
+  AND NOT is_folder
+  AND deleted_at IS NULL
+FOR UPDATE`, up.fileID, up.owner,
+		).Scan(&id)
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return id, err
+		}
+		// Deleted while the upload was in flight; go by name.
+	}
+
+	err := tx.QueryRow(ctx, `
+SELECT id FROM files
+WHERE owner_id = $1
+  AND parent_id IS NOT DISTINCT FROM $2
+  AND lower(name) = lower($3)
+  AND NOT is_folder
+  AND deleted_at IS NULL
+FOR UPDATE`, up.owner, parentArg(up.parent), up.name,
+	).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+
+	return id, err
+}
+
+// addVersion makes an uploaded object the file's newest, current version.
+func addVersion(ctx context.Context, q dbtx, fileID int64, key, mime string, size int64) (*gv1.FileItem, error) {
+	var version int
+	err := q.QueryRow(ctx,
+		`SELECT COALESCE(max(version), 0) + 1 FROM file_versions WHERE file_id = $1`,
+		fileID,
+	).Scan(&version)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = q.Exec(ctx,
+		`UPDATE file_versions SET superseded_at = now() WHERE file_id = $1 AND superseded_at IS NULL`,
+		fileID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = q.Exec(ctx, `
+INSERT INTO file_versions(file_id, version, object_key, mime, size_bytes)
+VALUES($1, $2, $3, $4, $5)`, fileID, version, key, mime, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanItem(q.QueryRow(ctx, `
+UPDATE files
+SET object_key = $2, mime = $3, size_bytes = $4, version_id = $5
+WHERE id = $1
+RETURNING `+itemColumns, fileID, key, mime, size, strconv.Itoa(version)))
+}
+
+// parseVersion reads a version_id as the API gives it out.
+func parseVersion(versionID string) (int, error) {
+	v, err := strconv.Atoi(versionID)
+	if err != nil || v < 1 {
+		return 0, status.Error(codes.InvalidArgument, "bad version id")
+	}
+
+	return v, nil
+}
+
+// ListVersions lists the versions of a file that are still kept, newest first.
+func (s *server) ListVersions(ctx context.Context, in *gv1.FileVersionsRequest) (*gv1.FileVersions, error) {
+	owner, err := authorize(ctx, s.db, in.OwnerId, in.FileId, canView)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx, `
+SELECT v.version, v.mime, v.size_bytes, v.created_at, v.superseded_at IS NULL
+FROM file_versions v
+JOIN files f ON f.id = v.file_id
+WHERE f.id = $1
+  AND f.owner_id = $2
+  AND f.deleted_at IS NULL
+ORDER BY v.version DESC`, in.FileId, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := &gv1.FileVersions{}
+	for rows.Next() {
+		var (
+			v       gv1.FileVersion
+			version int
+			created time.Time
+		)
+		if err := rows.Scan(&version, &v.Mime, &v.SizeBytes, &created, &v.Current); err != nil {
+			return nil, err
+		}
+		v.VersionId = strconv.Itoa(version)
+		v.CreatedAt = created.UTC().Format(time.RFC3339)
+		out.Versions = append(out.Versions, &v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(out.Versions) == 0 {
+		return nil, status.Error(codes.NotFound, "file not found")
+	}
+
+	return out, nil
+}
+
+// DownloadVersion presigns a download of one version of a file.
+func (s *server) DownloadVersion(ctx context.Context, in *gv1.VersionRequest) (*gv1.DownloadURLResponse, error) {
+	version, err := parseVersion(in.VersionId)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := authorize(ctx, s.db, in.OwnerId, in.FileId, canView)
+	if err != nil {
+		return nil, err
+	}
+
+	var objectKey string
+	err = s.db.QueryRow(ctx, `
+SELECT v.object_key
+FROM file_versions v
+JOIN files f ON f.id = v.file_id
+WHERE f.id = $1
+  AND f.owner_id = $2
+  AND f.deleted_at IS NULL
+  AND v.version = $3`, in.FileId, owner, version,
+	).Scan(&objectKey)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "version not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.storage.PresignDownload(identity.Forward(ctx), &gv1.PresignDownloadRequest{ObjectKey: objectKey})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		ActorID: in.OwnerId,
+		Action:  "files.download",
+		Target:  fmt.Sprintf("file:%d", in.FileId),
+		IP:      audit.ClientIP(ctx),
+		Details: map[string]string{"version": in.VersionId},
+	})
+
+	return &gv1.DownloadURLResponse{DownloadUrl: p.Url, ExpiresAt: p.ExpiresAt}, nil
+}
+
+// RestoreVersion makes an older version current again. The version that was
+// current is kept as an old one, so this can be undone the same way.
+func (s *server) RestoreVersion(ctx context.Context, in *gv1.VersionRequest) (*gv1.FileItem, error) {
+	version, err := parseVersion(in.VersionId)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	owner, err := authorize(ctx, tx, in.OwnerId, in.FileId, canEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	var current string
+	err = tx.QueryRow(ctx, `
+SELECT COALESCE(version_id, '')
+FROM files
+WHERE id = $1
+  AND owner_id = $2
+  AND NOT is_folder
+  AND deleted_at IS NULL
+FOR UPDATE`, in.FileId, owner,
+	).Scan(&current)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "file not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		objectKey, mime string
+		size            int64
+	)
+	err = tx.QueryRow(ctx, `
+UPDATE file_versions
+SET superseded_at = NULL
+WHERE file_id = $1
+  AND version = $2
+RETURNING object_key, mime, size_bytes`, in.FileId, version,
+	).Scan(&objectKey, &mime, &size)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "version not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+UPDATE file_versions
+SET superseded_at = now()
+WHERE file_id = $1
+  AND version <> $2
+  AND superseded_at IS NULL`, in.FileId, version)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := scanItem(tx.QueryRow(ctx, `
+UPDATE files
+SET object_key = $2, mime = $3, size_bytes = $4, version_id = $5
+WHERE id = $1
+RETURNING `+itemColumns, in.FileId, objectKey, mime, size, strconv.Itoa(version)))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		ActorID: in.OwnerId,
+		Action:  "files.version_restored",
+		Target:  fmt.Sprintf("file:%d", in.FileId),
+		IP:      audit.ClientIP(ctx),
+		Details: map[string]string{"version": in.VersionId, "previous_version": current},
+	})
+
+	return f, nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/http"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (d *deps) deleteAccount(c *gin.Context) {
+	var in struct {
+		Password string `json:"password"`
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	resp, err := d.auth.DeleteAccount(asCaller(c), &gv1.DeleteAccountRequest{Password: in.Password})
+	if err != nil {
+		if tooManyAttempts(c, err) {
+			return
+		}
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.PermissionDenied:
+				c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+				return
+			case codes.FailedPrecondition:
+				c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+				return
+			}
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "delete account failed"})
+		return
+	}
+
+	// Nothing is gone yet; the janitor erases the account after delete_after.
+	c.JSON(http.StatusAccepted, gin.H{"delete_after": resp.DeleteAfter})
+}
+
+func (d *deps) cancelAccountDeletion(c *gin.Context) {
+	if _, err := d.auth.CancelAccountDeletion(asCaller(c), &gv1.Empty{}); err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.FailedPrecondition {
+			c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "cancel failed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"cancelled": true})
+}
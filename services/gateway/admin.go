@@ -0,0 +1,149 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// accountSuspended answers 403 if err says the account is suspended, and
+// reports whether it did.
+func accountSuspended(c *gin.Context, err error) bool {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.PermissionDenied {
+		return false
+	}
+
+	c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+	return true
+}
+
+func (d *deps) searchUsers(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("page_size", "50"))
+
+	resp, err := d.admin.SearchUsers(asCaller(c), &gv1.SearchUsersRequest{
+		Query:    c.Query("q"),
+		Page:     int32(page),
+		PageSize: int32(size),
+	})
+	if err != nil {
+		adminError(c, err, "search users failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (d *deps) userUsage(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad user id"})
+		return
+	}
+
+	resp, err := d.admin.GetUserUsage(asCaller(c), &gv1.UserRequest{UserId: id})
+	if err != nil {
+		adminError(c, err, "usage lookup failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (d *deps) suspendUser(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad user id"})
+		return
+	}
+
+	// The reason is optional.
+	var in struct {
+		Reason string `json:"reason"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&in); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+			return
+		}
+	}
+
+	if _, err := d.admin.SuspendUser(asCaller(c), &gv1.SuspendUserRequest{UserId: id, Reason: in.Reason}); err != nil {
+		adminError(c, err, "suspend failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user_id": id, "suspended": true})
+}
+
+func (d *deps) unsuspendUser(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad user id"})
+		return
+	}
+
+	if _, err := d.admin.UnsuspendUser(asCaller(c), &gv1.UserRequest{UserId: id}); err != nil {
+		adminError(c, err, "unsuspend failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user_id": id, "suspended": false})
+}
+
+func (d *deps) forceLogout(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad user id"})
+		return
+	}
+
+	if _, err := d.admin.ForceLogout(asCaller(c), &gv1.UserRequest{UserId: id}); err != nil {
+		adminError(c, err, "logout failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user_id": id, "logged_out": true})
+}
+
+func (d *deps) triggerPasswordReset(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad user id"})
+		return
+	}
+
+	if _, err := d.admin.TriggerPasswordReset(asCaller(c), &gv1.UserRequest{UserId: id}); err != nil {
+		adminError(c, err, "password reset failed")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"user_id": id, "password_reset": "sent"})
+}
+
+func (d *deps) createSignupInvite(c *gin.Context) {
+	// The email is optional; without one the code works for any address.
+	var in struct {
+		Email string `json:"email"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&in); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+			return
+		}
+	}
+
+	inv, err := d.admin.CreateSignupInvite(asCaller(c), &gv1.CreateSignupInviteRequest{Email: in.Email})
+	if err != nil {
+		adminError(c, err, "create invite failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, inv)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// filesError maps a files service error to an HTTP response.
+func filesError(c *gin.Context, err error, fallback string) {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.InvalidArgument:
+			c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			return
+		case codes.NotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			return
+		case codes.AlreadyExists, codes.FailedPrecondition:
+			c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+			return
+		case codes.PermissionDenied:
+			c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			return
+		}
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+}
+
+// folderParam reads the :id of a folder route; "root" is the top level.
+func folderParam(c *gin.Context) (int64, bool) {
+	if c.Param("id") == "root" {
+		return 0, true
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad folder id"})
+		return 0, false
+	}
+
+	return id, true
+}
+
+// fileParam reads the :id of a file, or of an item in the trash.
+func fileParam(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad file id"})
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (d *deps) createFolder(c *gin.Context) {
+	var in struct {
+		Name     string `json:"name"`
+		ParentID int64  `json:"parent_id"` // optional; top level by default
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	f, err := d.files.CreateFolder(d.asUser(c), &gv1.CreateFolderRequest{
+		OwnerId:  c.GetInt64("uid"),
+		ParentId: in.ParentID,
+		Name:     in.Name,
+	})
+	if err != nil {
+		filesError(c, err, "create folder failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, f)
+}
+
+func (d *deps) listChildren(c *gin.Context) {
+	id, ok := folderParam(c)
+	if !ok {
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+
+	resp, err := d.files.ListChildren(d.asUser(c), &gv1.ListChildrenRequest{
+		OwnerId:  c.GetInt64("uid"),
+		FolderId: id,
+		Page:     int32(page),
+		PageSize: 50,
+	})
+	if err != nil {
+		filesError(c, err, "list failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// folderPath returns breadcrumbs for a folder (or file), top level first.
+func (d *deps) folderPath(c *gin.Context) {
+	id, ok := folderParam(c)
+	if !ok {
+		return
+	}
+	if id == 0 {
+		c.JSON(http.StatusOK, gin.H{"items": []any{}})
+		return
+	}
+
+	resp, err := d.files.GetPath(d.asUser(c), &gv1.FilePathRequest{OwnerId: c.GetInt64("uid"), FileId: id})
+	if err != nil {
+		filesError(c, err, "path lookup failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// resolvePath looks up a file or folder by path: GET /paths?path=Reports/2024.
+func (d *deps) resolvePath(c *gin.Context) {
+	f, err := d.files.ResolvePath(d.asUser(c), &gv1.ResolvePathRequest{
+		OwnerId: c.GetInt64("uid"),
+		Path:    c.Query("path"),
+	})
+	if err != nil {
+		filesError(c, err, "path lookup failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, f)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksCache holds auth's token verification keys so the gateway can verify
+// access tokens without a gRPC round trip per request.
+type jwksCache struct {
+	url    string
+	client *http.Client
+
+	mu      sync.RWMutex
+	keys    map[string]ed25519.PublicKey
+	lastTry time.Time // last on-demand refetch, successful or not
+}
+
+func newJWKSCache(url string) *jwksCache {
+	return &jwksCache{
+		url:    url,
+		client: &http.Client{Timeout: 5 * time.Second},
+		keys:   map[string]ed25519.PublicKey{},
+	}
+}
+
+// run fetches the key set now and then periodically, so keys removed after a
+// rotation stop being accepted.
+func (j *jwksCache) run(every time.Duration) {
+	if err := j.refresh(context.Background()); err != nil {
+		log.Printf("fetch JWKS: %v", err)
+	}
+
+	go func() {
+		for range time.Tick(every) {
+			if err := j.refresh(context.Background()); err != nil {
+				log.Printf("fetch JWKS: %v", err)
+			}
+		}
+	}()
+}
+
+func (j *jwksCache) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("JWKS status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Kid string `json:"kid"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return err
+	}
+
+	keys := map[string]ed25519.PublicKey{}
+	for _, k := range set.Keys {
+		if k.Kty != "OKP" || k.Crv != "Ed25519" {
+			continue
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			log.Printf("skip malformed JWK %q", k.Kid)
+			continue
+		}
+		keys[k.Kid] = ed25519.PublicKey(x)
+	}
+
+	j.mu.Lock()
+	j.keys = keys
+	j.mu.Unlock()
+
+	return nil
+}
+
+// keyfunc resolves the verification key for a token by its kid. An unknown
+// kid usually means auth just rotated in a new key, so we refetch once,
+// at most every 30 seconds so junk tokens can't hammer auth.
+func (j *jwksCache) keyfunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+
+	j.mu.Lock()
+	key, ok := j.keys[kid]
+	retry := !ok && time.Since(j.lastTry) > 30*time.Second
+	if retry {
+		j.lastTry = time.Now()
+	}
+	j.mu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+
+	if retry {
+		if err := j.refresh(context.Background()); err != nil {
+			return nil, err
+		}
+
+		j.mu.RLock()
+		key, ok = j.keys[kid]
+		j.mu.RUnlock()
+
+		if ok {
+			return key, nil
+		}
+	}
+
+	return nil, errors.New("unknown kid")
+}
@@ -1,7 +1,7 @@
 package main
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"net/http"
 	"os"
@@ -9,66 +9,195 @@ import (
 	"strings"
 	"time"
 
+	"godrive/internal/identity"
+	"godrive/internal/mtls"
 	gv1 "godrive/proto/godrive/v1"
 
 	"github.com/gin-gonic/gin"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type deps struct {
 	auth    gv1.AuthServiceClient
+	orgs    gv1.OrgServiceClient   // served by the auth service
+	admin   gv1.AdminServiceClient // served by the auth service
 	files   gv1.FilesServiceClient
 	storage gv1.StorageServiceClient
+	revoked *revocations
+	jwks    *jwksCache
+	ids     *identity.Signer // signs the user into calls to files and storage
+
+	// unverifiedMaxUpload caps upload size until the user verifies their email.
+	unverifiedMaxUpload int64
 }
 
 func main() {
 
-	authConn, err := grpc.NewClient(
-		env("AUTH_ADDR", "auth:50051"),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	// Backends only accept mutual TLS (see internal/mtls).
+	tlsSrc, err := mtls.Setup("gateway")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	authConn, err := tlsSrc.Dial(env("AUTH_ADDR", "auth:50051"))
 	if err != nil {
 		log.Fatalf("dial auth: %v", err)
 	}
 
-	filesConn, err := grpc.NewClient(
-		env("FILES_ADDR", "files:50052"),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	filesConn, err := tlsSrc.Dial(env("FILES_ADDR", "files:50052"))
 	if err != nil {
 		log.Fatalf("dial files: %v", err)
 	}
 
-	storageConn, err := grpc.NewClient(
-		env("STORAGE_ADDR", "storage:50053"),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	storageConn, err := tlsSrc.Dial(env("STORAGE_ADDR", "storage:50053"))
 	if err != nil {
 		log.Fatalf("dial storage: %v", err)
 	}
 
+	ids, err := identity.FromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	nc, err := nats.Connect(env("NATS_URL", "nats://nats:4222"))
+	if err != nil {
+		log.Fatalf("connect NATS: %v", err)
+	}
+	defer nc.Drain()
+
+	unverifiedMaxUpload, err := strconv.ParseInt(env("UNVERIFIED_MAX_UPLOAD_BYTES", "10485760"), 10, 64)
+	if err != nil {
+		log.Fatalf("invalid UNVERIFIED_MAX_UPLOAD_BYTES: %v", err)
+	}
+
 	d := &deps{
 		auth:    gv1.NewAuthServiceClient(authConn),
+		orgs:    gv1.NewOrgServiceClient(authConn),
+		admin:   gv1.NewAdminServiceClient(authConn),
 		files:   gv1.NewFilesServiceClient(filesConn),
 		storage: gv1.NewStorageServiceClient(storageConn),
+		revoked: newRevocations(),
+		jwks:    newJWKSCache(env("AUTH_JWKS_URL", "http://auth:8081/.well-known/jwks.json")),
+		ids:     ids,
+
+		unverifiedMaxUpload: unverifiedMaxUpload,
+	}
+
+	d.jwks.run(5 * time.Minute)
+
+	if err := d.revoked.subscribe(nc); err != nil {
+		log.Fatalf("subscribe revocations: %v", err)
 	}
 
 	r := gin.Default()
 
+	// Only believe X-Forwarded-For from known proxies; the client IP feeds
+	// login throttling, so it must not be spoofable.
+	var proxies []string
+	if v := env("TRUSTED_PROXIES", ""); v != "" {
+		proxies = strings.Split(v, ",")
+	}
+	if err := r.SetTrustedProxies(proxies); err != nil {
+		log.Fatalf("invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"ok": true, "time": time.Now().UTC()})
 	})
 
 	r.POST("/signup", d.signup)
 	r.POST("/login", d.login)
+	r.POST("/login/mfa", d.loginMFA)
+	r.POST("/login/magic-link", d.requestMagicLink)
+	r.POST("/login/magic-link/redeem", d.redeemMagicLink)
+	r.GET("/auth/oidc/login", d.oidcLogin)
+	r.GET("/auth/oidc/callback", d.oidcCallback)
+	r.POST("/token/refresh", d.refreshToken)
+	r.POST("/password/forgot", d.forgotPassword)
+	r.POST("/password/reset", d.resetPassword)
+	r.POST("/email/verify", d.verifyEmail)
+	r.POST("/email/verify/resend", d.resendVerification)
 
 	auth := r.Group("/", d.authz)
 	{
-		auth.GET("/files", d.listFiles)
-		auth.POST("/files/upload-intent", d.createUploadIntent)
-		auth.GET("/files/:id/download", d.downloadURL)
-		auth.DELETE("/files/:id", d.deleteFile)
+		auth.GET("/files", requireScope(scopeFilesRead), d.listFiles)
+		auth.POST("/files/upload-intent", requireScope(scopeFilesWrite), d.createUploadIntent)
+		auth.GET("/files/:id/download", requireScope(scopeFilesRead), d.downloadURL)
+		auth.PATCH("/files/:id", requireScope(scopeFilesWrite), d.updateFile)
+		auth.DELETE("/files/:id", requireScope(scopeFilesDelete), d.deleteFile)
+		auth.GET("/files/:id/versions", requireScope(scopeFilesRead), d.listVersions)
+		auth.GET("/files/:id/versions/:version/download", requireScope(scopeFilesRead), d.downloadVersion)
+		auth.POST("/files/:id/versions/:version/restore", requireScope(scopeFilesWrite), d.restoreVersion)
+		auth.GET("/files/:id/shares", requireScope(scopeFilesRead), d.listShares)
+		auth.POST("/files/:id/shares", requireScope(scopeFilesWrite), requireVerified, d.shareFile)
+		auth.DELETE("/files/:id/shares/:share", requireScope(scopeFilesWrite), d.unshare)
+		auth.GET("/shared-with-me", requireScope(scopeFilesRead), d.sharedWithMe)
+
+		auth.POST("/folders", requireScope(scopeFilesWrite), d.createFolder)
+		auth.GET("/folders/:id/children", requireScope(scopeFilesRead), d.listChildren)
+		auth.GET("/folders/:id/path", requireScope(scopeFilesRead), d.folderPath)
+		auth.GET("/paths", requireScope(scopeFilesRead), d.resolvePath)
+
+		auth.GET("/trash", requireScope(scopeFilesRead), d.listTrash)
+		auth.POST("/trash/:id/restore", requireScope(scopeFilesWrite), d.restoreFile)
+		auth.DELETE("/trash/:id", requireScope(scopeFilesDelete), d.purgeFile)
+		auth.DELETE("/trash", requireScope(scopeFilesDelete), d.emptyTrash)
+	}
+
+	// Account management needs an interactive login, not a personal access token.
+	session := r.Group("/", d.authz, requireSession)
+	{
+		session.POST("/logout", d.logout)
+		session.POST("/logout/all", d.logoutAll)
+
+		session.POST("/mfa/totp/enroll", d.enrollTOTP)
+		session.POST("/mfa/totp/confirm", d.confirmTOTP)
+
+		session.POST("/tokens", d.createAccessToken)
+		session.GET("/tokens", d.listAccessTokens)
+		session.DELETE("/tokens/:id", d.revokeAccessToken)
+
+		session.GET("/sessions", d.listSessions)
+		session.DELETE("/sessions/:id", d.revokeSession)
+
+		session.POST("/account/delete", d.deleteAccount)
+		session.POST("/account/delete/cancel", d.cancelAccountDeletion)
+
+		session.POST("/orgs", d.createOrg)
+		session.GET("/orgs", d.listOrgs)
+		session.GET("/orgs/:id/members", d.listOrgMembers)
+		session.POST("/orgs/:id/invitations", d.inviteOrgMember)
+		session.PUT("/orgs/:id/members/:uid/role", d.setOrgMemberRole)
+		session.DELETE("/orgs/:id/members/:uid", d.removeOrgMember)
+		session.POST("/orgs/:id/token", d.issueOrgToken)
+
+		session.GET("/invitations", d.listInvitations)
+		session.POST("/invitations/:id/accept", d.acceptInvitation)
+		session.POST("/invitations/:id/decline", d.declineInvitation)
+	}
+
+	// Auth enforces roles again on its side; checking here keeps other users
+	// from reaching it at all. Auditors may look but not change anything.
+	admin := r.Group("/admin", d.authz, requireSession, requireRole(roleAdmin, roleAuditor))
+	{
+		admin.GET("/users", d.searchUsers)
+		admin.GET("/users/:id/usage", d.userUsage)
+		admin.GET("/audit", d.queryAuditLog)
+		admin.GET("/audit/export", d.exportAuditLog)
+		admin.GET("/audit/verify", d.verifyAuditLog)
+
+		write := admin.Group("/", requireRole(roleAdmin))
+		write.PUT("/users/:id/role", d.setUserRole)
+		write.POST("/users/:id/mfa/reset", d.resetUserMFA)
+		write.POST("/users/:id/suspend", d.suspendUser)
+		write.POST("/users/:id/unsuspend", d.unsuspendUser)
+		write.POST("/users/:id/logout", d.forceLogout)
+		write.POST("/users/:id/password-reset", d.triggerPasswordReset)
+		write.POST("/signup-invites", d.createSignupInvite)
 	}
 
 	port := env("PORT", "8080")
@@ -81,8 +210,9 @@ func main() {
 
 func (d *deps) signup(c *gin.Context) {
 	var in struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
+		Email      string `json:"email"`
+		Password   string `json:"password"`
+		InviteCode string `json:"invite_code"`
 	}
 
 	if err := c.BindJSON(&in); err != nil {
@@ -90,9 +220,22 @@ func (d *deps) signup(c *gin.Context) {
 		return
 	}
 
-	u, err := d.auth.SignUp(c, &gv1.Credentials{Email: in.Email, Password: in.Password})
+	u, err := d.auth.SignUp(withClient(c), &gv1.SignUpRequest{Email: in.Email, Password: in.Password, InviteCode: in.InviteCode})
 	if err != nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "email exists"})
+		if tooManyAttempts(c, err) {
+			return
+		}
+		st, _ := status.FromError(err)
+		switch st.Code() {
+		case codes.InvalidArgument:
+			c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+		case codes.AlreadyExists:
+			c.JSON(http.StatusConflict, gin.H{"error": "email exists"})
+		case codes.PermissionDenied:
+			c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "signup failed"})
+		}
 		return
 	}
 
@@ -110,13 +253,266 @@ func (d *deps) login(c *gin.Context) {
 		return
 	}
 
-	t, err := d.auth.Login(c, &gv1.Credentials{Email: in.Email, Password: in.Password})
+	t, err := d.auth.Login(withClient(c), &gv1.Credentials{Email: in.Email, Password: in.Password})
 	if err != nil {
+		if tooManyAttempts(c, err) || accountSuspended(c, err) {
+			return
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": t.AccessToken, "expires_at": t.ExpiresAt})
+	if t.MfaRequired {
+		c.JSON(http.StatusOK, gin.H{"mfa_required": true, "mfa_token": t.MfaToken})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenJSON(t))
+}
+
+func (d *deps) loginMFA(c *gin.Context) {
+	var in struct {
+		MFAToken string `json:"mfa_token"`
+		Code     string `json:"code"`
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	t, err := d.auth.CompleteMFALogin(withClient(c), &gv1.MFALoginRequest{MfaToken: in.MFAToken, Code: in.Code})
+	if err != nil {
+		if tooManyAttempts(c, err) || accountSuspended(c, err) {
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid code"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenJSON(t))
+}
+
+func (d *deps) requestMagicLink(c *gin.Context) {
+	var in struct {
+		Email string `json:"email"`
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	if _, err := d.auth.RequestMagicLink(withClient(c), &gv1.MagicLinkRequest{Email: in.Email}); err != nil {
+		if tooManyAttempts(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "login link request failed"})
+		return
+	}
+
+	// Same answer whether or not the account exists.
+	c.JSON(http.StatusAccepted, gin.H{"ok": true})
+}
+
+func (d *deps) redeemMagicLink(c *gin.Context) {
+	var in struct {
+		Token string `json:"token"`
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	t, err := d.auth.CompleteMagicLinkLogin(withClient(c), &gv1.MagicLinkLoginRequest{Token: in.Token})
+	if err != nil {
+		if accountSuspended(c, err) {
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired login link"})
+		return
+	}
+
+	if t.MfaRequired {
+		c.JSON(http.StatusOK, gin.H{"mfa_required": true, "mfa_token": t.MfaToken})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenJSON(t))
+}
+
+// oidcStateCookie binds an OIDC login to the browser that started it, so a
+// callback URL can't be replayed in someone else's browser (login CSRF).
+const oidcStateCookie = "godrive_oidc_state"
+
+func (d *deps) oidcLogin(c *gin.Context) {
+	u, err := d.auth.BeginOIDCLogin(withClient(c), &gv1.Empty{})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.FailedPrecondition {
+			c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": "identity provider unavailable"})
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, u.State, 600, "/auth/oidc", "", c.Request.TLS != nil, true)
+	c.Redirect(http.StatusFound, u.Url)
+}
+
+func (d *deps) oidcCallback(c *gin.Context) {
+	if e := c.Query("error"); e != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "identity provider: " + e})
+		return
+	}
+
+	state := c.Query("state")
+	cookie, err := c.Cookie(oidcStateCookie)
+	if err != nil || state == "" || cookie != state {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "login state mismatch"})
+		return
+	}
+	c.SetCookie(oidcStateCookie, "", -1, "/auth/oidc", "", c.Request.TLS != nil, true)
+
+	t, err := d.auth.CompleteOIDCLogin(withClient(c), &gv1.OIDCCallback{Code: c.Query("code"), State: state})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.PermissionDenied {
+			c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "login failed"})
+		return
+	}
+
+	if t.MfaRequired {
+		c.JSON(http.StatusOK, gin.H{"mfa_required": true, "mfa_token": t.MfaToken})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenJSON(t))
+}
+
+func (d *deps) refreshToken(c *gin.Context) {
+	var in struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	t, err := d.auth.Refresh(withClient(c), &gv1.RefreshRequest{RefreshToken: in.RefreshToken})
+	if err != nil {
+		if accountSuspended(c, err) {
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid refresh token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokenJSON(t))
+}
+
+func (d *deps) forgotPassword(c *gin.Context) {
+	var in struct {
+		Email string `json:"email"`
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	if _, err := d.auth.RequestPasswordReset(withClient(c), &gv1.PasswordResetRequest{Email: in.Email}); err != nil {
+		if tooManyAttempts(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "reset request failed"})
+		return
+	}
+
+	// Same answer whether or not the account exists.
+	c.JSON(http.StatusAccepted, gin.H{"ok": true})
+}
+
+func (d *deps) resetPassword(c *gin.Context) {
+	var in struct {
+		Token       string `json:"token"`
+		NewPassword string `json:"new_password"`
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	_, err := d.auth.ResetPassword(withClient(c), &gv1.ResetPasswordRequest{Token: in.Token, NewPassword: in.NewPassword})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "reset failed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"ok": true})
+}
+
+func (d *deps) verifyEmail(c *gin.Context) {
+	var in struct {
+		Token string `json:"token"`
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	if _, err := d.auth.VerifyEmail(withClient(c), &gv1.VerifyEmailRequest{Token: in.Token}); err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "verification failed"})
+		return
+	}
+
+	// Existing access tokens still say unverified; the client should refresh.
+	c.JSON(http.StatusOK, gin.H{"ok": true})
+}
+
+func (d *deps) resendVerification(c *gin.Context) {
+	var in struct {
+		Email string `json:"email"`
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	if _, err := d.auth.ResendVerification(withClient(c), &gv1.ResendVerificationRequest{Email: in.Email}); err != nil {
+		if tooManyAttempts(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "resend failed"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"ok": true})
+}
+
+func tokenJSON(t *gv1.Token) gin.H {
+	return gin.H{
+		"token":              t.AccessToken,
+		"expires_at":         t.ExpiresAt,
+		"refresh_token":      t.RefreshToken,
+		"refresh_expires_at": t.RefreshExpiresAt,
+	}
 }
 
 func (d *deps) authz(c *gin.Context) {
@@ -128,21 +524,155 @@ func (d *deps) authz(c *gin.Context) {
 
 	tok := strings.TrimPrefix(ah, "Bearer ")
 
-	u, err := d.auth.Verify(c, &gv1.Token{AccessToken: tok})
-	if err != nil {
+	// Personal access tokens are opaque; only auth can check them (and
+	// revocation is then immediate).
+	if strings.HasPrefix(tok, patPrefix) {
+		u, err := d.auth.Verify(withClient(c), &gv1.Token{AccessToken: tok})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return
+		}
+
+		c.Set("uid", u.Id)
+		c.Set("email_verified", u.EmailVerified)
+		if u.EmailVerified {
+			c.Set("verified_email", u.Email)
+		}
+		c.Set("role", u.Role)
+		c.Set("org_id", u.OrgId)
+		c.Set("pat", true)
+		c.Set("scopes", u.Scopes)
+		c.Next()
+		return
+	}
+
+	// Verify locally against auth's published keys; revocations arrive over NATS.
+	var claims tokenClaims
+	_, err := jwt.ParseWithClaims(tok, &claims, d.jwks.keyfunc,
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || d.revoked.revoked(&claims) {
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
 		return
 	}
 
-	c.Set("uid", u.Id)
+	c.Set("uid", claims.UID)
+	c.Set("email_verified", claims.EV)
+	if claims.EV {
+		c.Set("verified_email", claims.Email)
+	}
+	c.Set("role", claims.Role)
+	c.Set("org_id", claims.Org)
+	c.Set("token", tok)
 	c.Next()
 }
 
+func (d *deps) logout(c *gin.Context) {
+	var in struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	// The body is optional; the access token alone is enough to log out.
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&in); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+			return
+		}
+	}
+
+	_, err := d.auth.Logout(withClient(c), &gv1.Token{AccessToken: c.GetString("token"), RefreshToken: in.RefreshToken})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "logout failed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"ok": true})
+}
+
+func (d *deps) logoutAll(c *gin.Context) {
+	_, err := d.auth.LogoutAllSessions(withClient(c), &gv1.Token{AccessToken: c.GetString("token")})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "logout failed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"ok": true})
+}
+
+func (d *deps) enrollTOTP(c *gin.Context) {
+	e, err := d.auth.EnrollTOTP(asCaller(c), &gv1.Empty{})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.FailedPrecondition {
+			c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "enroll failed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"secret": e.Secret, "otpauth_url": e.OtpauthUrl})
+}
+
+func (d *deps) confirmTOTP(c *gin.Context) {
+	var in struct {
+		Code string `json:"code"`
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	rc, err := d.auth.ConfirmTOTP(asCaller(c), &gv1.TOTPCode{Code: in.Code})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			switch st.Code() {
+			case codes.InvalidArgument:
+				c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+				return
+			case codes.FailedPrecondition:
+				c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+				return
+			}
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "confirm failed"})
+		return
+	}
+
+	// Recovery codes are only ever shown here; auth keeps just their hashes.
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": rc.Codes})
+}
+
+// asCaller forwards the caller's access token so auth can act on their
+// behalf, along with their client info (see withClient).
+func asCaller(c *gin.Context) context.Context {
+	return metadata.AppendToOutgoingContext(withClient(c), "authorization", "Bearer "+c.GetString("token"))
+}
+
+// withClient forwards the end user's IP and user agent, for login
+// throttling, the session list and the audit log; otherwise every request
+// would appear to come from the gateway.
+func withClient(c *gin.Context) context.Context {
+	return metadata.AppendToOutgoingContext(c,
+		"x-client-ip", c.ClientIP(),
+		"x-user-agent", c.Request.UserAgent(),
+	)
+}
+
+// asUser signs the authenticated user into the call (see internal/identity),
+// with their verified email and their client info. Files and storage check
+// requests against it.
+func (d *deps) asUser(c *gin.Context) context.Context {
+	return d.ids.OutgoingVerified(withClient(c), c.GetInt64("uid"), c.GetString("verified_email"))
+}
+
 func (d *deps) listFiles(c *gin.Context) {
 	uid := c.GetInt64("uid")
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 
-	resp, err := d.files.List(c, &gv1.ListFilesRequest{
+	resp, err := d.files.List(d.asUser(c), &gv1.ListFilesRequest{
 		OwnerId:  uid,
 		Page:     int32(page),
 		PageSize: 20,
@@ -162,6 +692,8 @@ func (d *deps) createUploadIntent(c *gin.Context) {
 		Filename  string `json:"filename"`
 		Mime      string `json:"mime"`
 		SizeBytes int64  `json:"size_bytes"`
+		FolderID  int64  `json:"folder_id"` // optional; top level by default
+		FileID    int64  `json:"file_id"`   // optional; upload a new version of this file
 	}
 
 	if err := c.BindJSON(&in); err != nil {
@@ -169,36 +701,70 @@ func (d *deps) createUploadIntent(c *gin.Context) {
 		return
 	}
 
-	key := fmt.Sprintf("user/%d/%d_%s", uid, time.Now().UnixNano(), in.Filename)
+	// The declared size is only a hint; for unverified users storage also
+	// presigns a POST policy that holds the object itself to the cap.
+	var maxBytes int64
+	if !c.GetBool("email_verified") {
+		if in.SizeBytes > d.unverifiedMaxUpload {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":     "verify your email to upload larger files",
+				"max_bytes": d.unverifiedMaxUpload,
+			})
+			return
+		}
+		maxBytes = d.unverifiedMaxUpload
+	}
 
-	p, err := d.storage.PresignUpload(c, &gv1.PresignUploadRequest{
+	// Files checks the folder (or file) and picks the object key.
+	prep, err := d.files.PrepareUpload(d.asUser(c), &gv1.PrepareUploadRequest{
+		OwnerId:   uid,
+		FolderId:  in.FolderID,
+		FileId:    in.FileID,
+		Filename:  in.Filename,
+		Mime:      in.Mime,
+		SizeBytes: in.SizeBytes,
+	})
+	if err != nil {
+		filesError(c, err, "upload intent failed")
+		return
+	}
+	key := prep.ObjectKey
+
+	p, err := d.storage.PresignUpload(d.asUser(c), &gv1.PresignUploadRequest{
 		ObjectKey: key,
 		Mime:      in.Mime,
 		SizeBytes: in.SizeBytes,
+		MaxBytes:  maxBytes,
 	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "presign failed"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	out := gin.H{
 		"upload_url": p.Url,
+		"method":     p.Method,
 		"object_key": key,
 		"headers":    p.Headers,
 		"expires_at": p.ExpiresAt,
-	})
+	}
+	if p.Method == "POST" {
+		out["fields"] = p.Fields
+	}
+
+	c.JSON(http.StatusOK, out)
 }
 
 func (d *deps) downloadURL(c *gin.Context) {
 	uid := c.GetInt64("uid")
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 
-	resp, err := d.files.GetDownloadURL(c, &gv1.DownloadURLRequest{
+	resp, err := d.files.GetDownloadURL(d.asUser(c), &gv1.DownloadURLRequest{
 		OwnerId: uid,
 		FileId:  id,
 	})
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		filesError(c, err, "download failed")
 		return
 	}
 
@@ -209,8 +775,12 @@ func (d *deps) deleteFile(c *gin.Context) {
 	uid := c.GetInt64("uid")
 	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
 
-	resp, err := d.files.Delete(c, &gv1.DeleteFileRequest{OwnerId: uid, FileId: id})
-	if err != nil || !resp.Ok {
+	resp, err := d.files.Delete(d.asUser(c), &gv1.DeleteFileRequest{OwnerId: uid, FileId: id})
+	if err != nil {
+		filesError(c, err, "delete failed")
+		return
+	}
+	if !resp.Ok {
 		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
 		return
 	}
@@ -218,6 +788,38 @@ func (d *deps) deleteFile(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"deleted": id})
 }
 
+// updateFile renames a file or folder and/or moves it to another folder.
+// Fields left out of the body stay as they are.
+func (d *deps) updateFile(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad file id"})
+		return
+	}
+
+	var in struct {
+		Name     *string `json:"name"`
+		FolderID *int64  `json:"folder_id"` // 0 moves it to the top level
+	}
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	f, err := d.files.UpdateFile(d.asUser(c), &gv1.UpdateFileRequest{
+		OwnerId:  c.GetInt64("uid"),
+		FileId:   id,
+		Name:     in.Name,
+		ParentId: in.FolderID,
+	})
+	if err != nil {
+		filesError(c, err, "update failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, f)
+}
+
 func env(k, d string) string {
 	if v := os.Getenv(k); v != "" {
 		return v
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksServer serves pub as auth's JWKS, under kid.
+func jwksServer(t *testing.T, kid string, pub ed25519.PublicKey) *jwksCache {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "OKP",
+			"crv": "Ed25519",
+			"kid": kid,
+			"x":   base64.RawURLEncoding.EncodeToString(pub),
+		}}})
+	}))
+	t.Cleanup(srv.Close)
+
+	j := newJWKSCache(srv.URL)
+	if err := j.refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	return j
+}
+
+func newKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pub, priv
+}
+
+// sign makes a token as auth does, with kid in its header.
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, c tokenClaims) string {
+	t.Helper()
+
+	tok := jwt.NewWithClaims(method, c)
+	tok.Header["kid"] = kid
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+// claims are valid claims for uid, issued now.
+func claims(uid int64, jti string) tokenClaims {
+	now := time.Now()
+
+	return tokenClaims{UID: uid, SID: "family-1", EV: true, Email: "a@example.com", Role: "user",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(15 * time.Minute)),
+		}}
+}
+
+// authorized runs authz, then the handlers, and returns the status and
+// what authz set for them.
+func authorized(d *deps, header string, handlers ...gin.HandlerFunc) (int, map[any]any) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, r := gin.CreateTestContext(w)
+
+	var keys map[any]any
+	r.GET("/", append([]gin.HandlerFunc{d.authz}, append(handlers, func(c *gin.Context) {
+		keys = c.Keys
+		c.Status(http.StatusOK)
+	})...)...)
+
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	if header != "" {
+		c.Request.Header.Set("Authorization", header)
+	}
+	r.HandleContext(c)
+
+	return w.Code, keys
+}
+
+func TestAuthzJWT(t *testing.T) {
+	pub, priv := newKey(t)
+	_, otherPriv := newKey(t)
+
+	d := &deps{jwks: jwksServer(t, "k1", pub), revoked: newRevocations()}
+	d.revoked.add(revocation{JTI: "revoked-jti", ExpiresAt: time.Now().Add(time.Hour)})
+	d.revoked.add(revocation{SessionID: "revoked-family", ExpiresAt: time.Now().Add(time.Hour)})
+	d.revoked.add(revocation{UserID: 9, NotBefore: time.Now().Add(time.Second), ExpiresAt: time.Now().Add(time.Hour)})
+
+	expired := claims(1, "j")
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	noExpiry := claims(1, "j")
+	noExpiry.ExpiresAt = nil
+	future := claims(1, "j")
+	future.IssuedAt = jwt.NewNumericDate(time.Now().Add(time.Hour))
+	revokedSession := claims(1, "j")
+	revokedSession.SID = "revoked-family"
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{"valid", "Bearer " + sign(t, jwt.SigningMethodEdDSA, "k1", priv, claims(1, "j")), http.StatusOK},
+		{"no header", "", http.StatusUnauthorized},
+		{"not bearer", "Basic " + sign(t, jwt.SigningMethodEdDSA, "k1", priv, claims(1, "j")), http.StatusUnauthorized},
+		{"garbage", "Bearer not-a-token", http.StatusUnauthorized},
+		{"other key", "Bearer " + sign(t, jwt.SigningMethodEdDSA, "k1", otherPriv, claims(1, "j")), http.StatusUnauthorized},
+		{"unknown kid", "Bearer " + sign(t, jwt.SigningMethodEdDSA, "k2", otherPriv, claims(1, "j")), http.StatusUnauthorized},
+		{"HMAC with the public key", "Bearer " + sign(t, jwt.SigningMethodHS256, "k1", []byte(pub), claims(1, "j")), http.StatusUnauthorized},
+		{"unsigned", "Bearer " + sign(t, jwt.SigningMethodNone, "k1", jwt.UnsafeAllowNoneSignatureType, claims(1, "j")), http.StatusUnauthorized},
+		{"expired", "Bearer " + sign(t, jwt.SigningMethodEdDSA, "k1", priv, expired), http.StatusUnauthorized},
+		{"no expiry", "Bearer " + sign(t, jwt.SigningMethodEdDSA, "k1", priv, noExpiry), http.StatusUnauthorized},
+		{"issued in the future", "Bearer " + sign(t, jwt.SigningMethodEdDSA, "k1", priv, future), http.StatusUnauthorized},
+		{"revoked token", "Bearer " + sign(t, jwt.SigningMethodEdDSA, "k1", priv, claims(1, "revoked-jti")), http.StatusUnauthorized},
+		{"revoked session", "Bearer " + sign(t, jwt.SigningMethodEdDSA, "k1", priv, revokedSession), http.StatusUnauthorized},
+		{"logged out everywhere", "Bearer " + sign(t, jwt.SigningMethodEdDSA, "k1", priv, claims(9, "j")), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, keys := authorized(d, tt.header)
+			if code != tt.want {
+				t.Fatalf("status = %d, want %d", code, tt.want)
+			}
+			if code == http.StatusOK && (keys["uid"] != int64(1) || keys["verified_email"] != "a@example.com" || keys["pat"] != nil) {
+				t.Errorf("authz set %v", keys)
+			}
+		})
+	}
+}
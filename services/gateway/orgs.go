@@ -0,0 +1,175 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// orgError maps the status codes OrgService returns to HTTP.
+func orgError(c *gin.Context, err error, fallback string) {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.InvalidArgument:
+			c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			return
+		case codes.NotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			return
+		case codes.PermissionDenied:
+			c.JSON(http.StatusForbidden, gin.H{"error": st.Message()})
+			return
+		case codes.AlreadyExists, codes.FailedPrecondition, codes.ResourceExhausted:
+			c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+			return
+		}
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+}
+
+func (d *deps) createOrg(c *gin.Context) {
+	var in struct {
+		Name string `json:"name"`
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	org, err := d.orgs.CreateOrg(asCaller(c), &gv1.CreateOrgRequest{Name: in.Name})
+	if err != nil {
+		orgError(c, err, "create org failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, org)
+}
+
+func (d *deps) listOrgs(c *gin.Context) {
+	resp, err := d.orgs.ListOrgs(asCaller(c), &gv1.Empty{})
+	if err != nil {
+		orgError(c, err, "list orgs failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (d *deps) listOrgMembers(c *gin.Context) {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+
+	resp, err := d.orgs.ListMembers(asCaller(c), &gv1.OrgRequest{OrgId: id})
+	if err != nil {
+		orgError(c, err, "list members failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (d *deps) inviteOrgMember(c *gin.Context) {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+
+	var in struct {
+		Email string `json:"email"`
+		Role  string `json:"role"`
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	inv, err := d.orgs.InviteMember(asCaller(c), &gv1.InviteMemberRequest{OrgId: id, Email: in.Email, Role: in.Role})
+	if err != nil {
+		orgError(c, err, "invite failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, inv)
+}
+
+func (d *deps) setOrgMemberRole(c *gin.Context) {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	uid, _ := strconv.ParseInt(c.Param("uid"), 10, 64)
+
+	var in struct {
+		Role string `json:"role"`
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	_, err := d.orgs.SetMemberRole(asCaller(c), &gv1.OrgMemberRequest{OrgId: id, UserId: uid, Role: in.Role})
+	if err != nil {
+		orgError(c, err, "set role failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"org_id": id, "user_id": uid, "role": in.Role})
+}
+
+func (d *deps) removeOrgMember(c *gin.Context) {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+	uid, _ := strconv.ParseInt(c.Param("uid"), 10, 64)
+
+	if _, err := d.orgs.RemoveMember(asCaller(c), &gv1.OrgMemberRequest{OrgId: id, UserId: uid}); err != nil {
+		orgError(c, err, "remove member failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"removed": uid})
+}
+
+func (d *deps) issueOrgToken(c *gin.Context) {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+
+	t, err := d.orgs.IssueOrgToken(asCaller(c), &gv1.OrgRequest{OrgId: id})
+	if err != nil {
+		orgError(c, err, "issue token failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"access_token": t.AccessToken, "expires_at": t.ExpiresAt})
+}
+
+func (d *deps) listInvitations(c *gin.Context) {
+	resp, err := d.orgs.ListInvitations(asCaller(c), &gv1.Empty{})
+	if err != nil {
+		orgError(c, err, "list invitations failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (d *deps) acceptInvitation(c *gin.Context) {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+
+	org, err := d.orgs.AcceptInvitation(asCaller(c), &gv1.InvitationRequest{Id: id})
+	if err != nil {
+		orgError(c, err, "accept failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, org)
+}
+
+func (d *deps) declineInvitation(c *gin.Context) {
+	id, _ := strconv.ParseInt(c.Param("id"), 10, 64)
+
+	if _, err := d.orgs.DeclineInvitation(asCaller(c), &gv1.InvitationRequest{Id: id}); err != nil {
+		orgError(c, err, "decline failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"declined": id})
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAuth verifies personal access tokens the way auth does, from a fixed set.
+type fakeAuth struct {
+	gv1.AuthServiceClient
+	tokens map[string]*gv1.User
+}
+
+func (f *fakeAuth) Verify(ctx context.Context, in *gv1.Token, _ ...grpc.CallOption) (*gv1.User, error) {
+	// auth throttles and audits by the caller's IP.
+	if md, _ := metadata.FromOutgoingContext(ctx); len(md.Get("x-client-ip")) == 0 {
+		return nil, status.Error(codes.Internal, "no client IP")
+	}
+	u, ok := f.tokens[in.AccessToken]
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	return u, nil
+}
+
+func TestAuthzPAT(t *testing.T) {
+	pub, priv := newKey(t)
+	d := &deps{
+		jwks:    jwksServer(t, "k1", pub),
+		revoked: newRevocations(),
+		auth: &fakeAuth{tokens: map[string]*gv1.User{
+			"gdp_read":  {Id: 1, Email: "a@example.com", EmailVerified: true, Role: "user", Scopes: []string{scopeFilesRead}},
+			"gdp_write": {Id: 1, Email: "a@example.com", Role: "user", Scopes: []string{scopeFilesRead, scopeFilesWrite}},
+		}},
+	}
+	session := "Bearer " + sign(t, jwt.SigningMethodEdDSA, "k1", priv, claims(1, "j"))
+
+	tests := []struct {
+		name     string
+		header   string
+		handlers []gin.HandlerFunc
+		want     int
+	}{
+		{"read token", "Bearer gdp_read", nil, http.StatusOK},
+		{"unknown token", "Bearer gdp_other", nil, http.StatusUnauthorized},
+		{"in scope", "Bearer gdp_read", []gin.HandlerFunc{requireScope(scopeFilesRead)}, http.StatusOK},
+		{"out of scope", "Bearer gdp_read", []gin.HandlerFunc{requireScope(scopeFilesWrite)}, http.StatusForbidden},
+		{"one of several scopes", "Bearer gdp_write", []gin.HandlerFunc{requireScope(scopeFilesWrite)}, http.StatusOK},
+		{"no delete scope", "Bearer gdp_write", []gin.HandlerFunc{requireScope(scopeFilesDelete)}, http.StatusForbidden},
+		{"session has every scope", session, []gin.HandlerFunc{requireScope(scopeFilesDelete)}, http.StatusOK},
+		{"token on session route", "Bearer gdp_write", []gin.HandlerFunc{requireSession}, http.StatusForbidden},
+		{"session on session route", session, []gin.HandlerFunc{requireSession}, http.StatusOK},
+		{"token, unverified email", "Bearer gdp_write", []gin.HandlerFunc{requireVerified}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, keys := authorized(d, tt.header, tt.handlers...)
+			if code != tt.want {
+				t.Fatalf("status = %d, want %d", code, tt.want)
+			}
+			if code != http.StatusOK || tt.header == session {
+				return
+			}
+			if keys["uid"] != int64(1) || keys["pat"] != true {
+				t.Errorf("authz set %v", keys)
+			}
+			if scopes, _ := keys["scopes"].([]string); !slices.Contains(scopes, scopeFilesRead) {
+				t.Errorf("scopes = %v", keys["scopes"])
+			}
+		})
+	}
+}
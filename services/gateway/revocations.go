@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nats-io/nats.go"
+)
+
+// revocationSubject must match the subject auth publishes on.
+const revocationSubject = "godrive.auth.revoked"
+
+// revocation mirrors the payload auth broadcasts when tokens are revoked.
+type revocation struct {
+	JTI       string    `json:"jti,omitempty"`
+	SessionID string    `json:"sid,omitempty"`
+	UserID    int64     `json:"uid,omitempty"`
+	NotBefore time.Time `json:"not_before,omitempty"`
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// revocations is an in-memory view of recent revocations, fed by NATS.
+// It lets every replica reject a revoked token within seconds, without
+// waiting for the token to expire.
+type revocations struct {
+	mu       sync.RWMutex
+	jtis     map[string]time.Time // jti -> when we can forget it
+	sessions map[string]time.Time // sid -> when we can forget it
+	users    map[int64]revocation // uid -> latest "log out everywhere"
+}
+
+func newRevocations() *revocations {
+	return &revocations{
+		jtis:     map[string]time.Time{},
+		sessions: map[string]time.Time{},
+		users:    map[int64]revocation{},
+	}
+}
+
+// subscribe starts consuming revocations. Every replica needs every event,
+// so this is a plain subscription, not a queue group.
+func (r *revocations) subscribe(nc *nats.Conn) error {
+	_, err := nc.Subscribe(revocationSubject, func(msg *nats.Msg) {
+		var ev revocation
+		if err := json.Unmarshal(msg.Data, &ev); err != nil {
+			log.Printf("bad revocation event: %v", err)
+			return
+		}
+		r.add(ev)
+	})
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for range time.Tick(time.Minute) {
+			r.prune(time.Now())
+		}
+	}()
+
+	return nil
+}
+
+func (r *revocations) add(ev revocation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ev.JTI != "" {
+		r.jtis[ev.JTI] = ev.ExpiresAt
+	}
+	if ev.SessionID != "" {
+		r.sessions[ev.SessionID] = ev.ExpiresAt
+	}
+	if ev.UserID != 0 {
+		if cur, ok := r.users[ev.UserID]; !ok || ev.NotBefore.After(cur.NotBefore) {
+			r.users[ev.UserID] = ev
+		}
+	}
+}
+
+func (r *revocations) prune(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for jti, exp := range r.jtis {
+		if now.After(exp) {
+			delete(r.jtis, jti)
+		}
+	}
+	for sid, exp := range r.sessions {
+		if now.After(exp) {
+			delete(r.sessions, sid)
+		}
+	}
+	for uid, ev := range r.users {
+		if now.After(ev.ExpiresAt) {
+			delete(r.users, uid)
+		}
+	}
+}
+
+// revoked reports whether the token with these claims has been revoked.
+func (r *revocations) revoked(c *tokenClaims) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.jtis[c.ID]; ok {
+		return true
+	}
+	if _, ok := r.sessions[c.SID]; ok && c.SID != "" {
+		return true
+	}
+	if ev, ok := r.users[c.UID]; ok && c.IssuedAt != nil && c.IssuedAt.Time.Before(ev.NotBefore) {
+		return true
+	}
+
+	return false
+}
+
+// tokenClaims is the subset of auth's access token claims the gateway reads.
+type tokenClaims struct {
+	UID   int64  `json:"uid"`
+	SID   string `json:"sid,omitempty"`
+	EV    bool   `json:"ev"`
+	Email string `json:"email,omitempty"` // set once verified
+	Role  string `json:"role,omitempty"`
+	Org   int64  `json:"org,omitempty"`
+	jwt.RegisteredClaims
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Roles, as carried in the "role" claim (see internal/rbac).
+const (
+	roleUser    = "user"
+	roleAdmin   = "admin"
+	roleAuditor = "auditor"
+)
+
+// requireRole lets the request through only if the caller has one of roles.
+// Tokens without a role claim are plain users.
+func requireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		if role == "" {
+			role = roleUser
+		}
+		if !slices.Contains(roles, role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient role"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func (d *deps) setUserRole(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad user id"})
+		return
+	}
+
+	var in struct {
+		Role string `json:"role"`
+	}
+
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	if _, err := d.auth.SetUserRole(asCaller(c), &gv1.SetUserRoleRequest{UserId: id, Role: in.Role}); err != nil {
+		adminError(c, err, "set role failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user_id": id, "role": in.Role})
+}
+
+func (d *deps) resetUserMFA(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad user id"})
+		return
+	}
+
+	if _, err := d.auth.AdminResetMFA(asCaller(c), &gv1.ResetMFARequest{UserId: id}); err != nil {
+		adminError(c, err, "mfa reset failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user_id": id, "mfa": "reset"})
+}
+
+// adminError maps the status codes admin RPCs return to HTTP.
+func adminError(c *gin.Context, err error, fallback string) {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.InvalidArgument:
+			c.JSON(http.StatusBadRequest, gin.H{"error": st.Message()})
+			return
+		case codes.NotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": st.Message()})
+			return
+		case codes.FailedPrecondition:
+			c.JSON(http.StatusConflict, gin.H{"error": st.Message()})
+			return
+		case codes.PermissionDenied:
+			c.JSON(http.StatusForbidden, gin.H{"error": "insufficient role"})
+			return
+		case codes.Unauthenticated:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return
+		}
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"net/http"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (d *deps) listSessions(c *gin.Context) {
+	resp, err := d.auth.ListSessions(asCaller(c), &gv1.Empty{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "list sessions failed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (d *deps) revokeSession(c *gin.Context) {
+	id := c.Param("id")
+
+	if _, err := d.auth.RevokeSession(asCaller(c), &gv1.RevokeSessionRequest{Id: id}); err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "revoke session failed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revoked": id})
+}
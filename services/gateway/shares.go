@@ -0,0 +1,107 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requireVerified rejects users who haven't verified their email yet, for
+// what unverified accounts may not do, like sharing.
+func requireVerified(c *gin.Context) {
+	if !c.GetBool("email_verified") {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "verify your email first"})
+		return
+	}
+	c.Next()
+}
+
+// shareFile grants a user, by ID or email, a role on a file or folder.
+func (d *deps) shareFile(c *gin.Context) {
+	id, ok := fileParam(c)
+	if !ok {
+		return
+	}
+
+	var in struct {
+		UserID int64  `json:"user_id"`
+		Email  string `json:"email"`
+		Role   string `json:"role"` // viewer, commenter or editor
+	}
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad payload"})
+		return
+	}
+
+	sh, err := d.files.ShareFile(d.asUser(c), &gv1.ShareRequest{
+		OwnerId: c.GetInt64("uid"),
+		FileId:  id,
+		UserId:  in.UserID,
+		Email:   in.Email,
+		Role:    in.Role,
+	})
+	if err != nil {
+		filesError(c, err, "share failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, sh)
+}
+
+func (d *deps) listShares(c *gin.Context) {
+	id, ok := fileParam(c)
+	if !ok {
+		return
+	}
+
+	resp, err := d.files.ListShares(d.asUser(c), &gv1.SharesRequest{OwnerId: c.GetInt64("uid"), FileId: id})
+	if err != nil {
+		filesError(c, err, "list shares failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (d *deps) unshare(c *gin.Context) {
+	id, ok := fileParam(c)
+	if !ok {
+		return
+	}
+	shareID, err := strconv.ParseInt(c.Param("share"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad share id"})
+		return
+	}
+
+	_, err = d.files.Unshare(d.asUser(c), &gv1.UnshareRequest{
+		OwnerId: c.GetInt64("uid"),
+		FileId:  id,
+		ShareId: shareID,
+	})
+	if err != nil {
+		filesError(c, err, "unshare failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"unshared": shareID})
+}
+
+func (d *deps) sharedWithMe(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+
+	resp, err := d.files.ListSharedWithMe(d.asUser(c), &gv1.SharedWithMeRequest{
+		OwnerId:  c.GetInt64("uid"),
+		Page:     int32(page),
+		PageSize: 50,
+	})
+	if err != nil {
+		filesError(c, err, "list failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
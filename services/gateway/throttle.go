@@ -0,0 +1,31 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tooManyAttempts answers 429 with Retry-After if err says auth is
+// throttling the caller, and reports whether it did.
+func tooManyAttempts(c *gin.Context, err error) bool {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		return false
+	}
+
+	for _, d := range st.Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok && ri.RetryDelay != nil {
+			secs := int(math.Ceil(ri.RetryDelay.AsDuration().Seconds()))
+			c.Header("Retry-After", strconv.Itoa(max(secs, 1)))
+		}
+	}
+
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": st.Message()})
+	return true
+}
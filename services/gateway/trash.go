@@ -0,0 +1,66 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (d *deps) listTrash(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+
+	resp, err := d.files.ListTrash(d.asUser(c), &gv1.ListTrashRequest{
+		OwnerId:  c.GetInt64("uid"),
+		Page:     int32(page),
+		PageSize: 50,
+	})
+	if err != nil {
+		filesError(c, err, "list failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (d *deps) restoreFile(c *gin.Context) {
+	id, ok := fileParam(c)
+	if !ok {
+		return
+	}
+
+	f, err := d.files.Restore(d.asUser(c), &gv1.TrashRequest{OwnerId: c.GetInt64("uid"), FileId: id})
+	if err != nil {
+		filesError(c, err, "restore failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, f)
+}
+
+// purgeFile deletes an item in the trash for good.
+func (d *deps) purgeFile(c *gin.Context) {
+	id, ok := fileParam(c)
+	if !ok {
+		return
+	}
+
+	if _, err := d.files.PurgeNow(d.asUser(c), &gv1.TrashRequest{OwnerId: c.GetInt64("uid"), FileId: id}); err != nil {
+		filesError(c, err, "purge failed")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"purged": id})
+}
+
+// emptyTrash deletes everything in the trash for good.
+func (d *deps) emptyTrash(c *gin.Context) {
+	if _, err := d.files.PurgeNow(d.asUser(c), &gv1.TrashRequest{OwnerId: c.GetInt64("uid")}); err != nil {
+		filesError(c, err, "purge failed")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"ok": true})
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"net/http"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (d *deps) listVersions(c *gin.Context) {
+	id, ok := fileParam(c)
+	if !ok {
+		return
+	}
+
+	resp, err := d.files.ListVersions(d.asUser(c), &gv1.FileVersionsRequest{OwnerId: c.GetInt64("uid"), FileId: id})
+	if err != nil {
+		filesError(c, err, "list versions failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (d *deps) downloadVersion(c *gin.Context) {
+	id, ok := fileParam(c)
+	if !ok {
+		return
+	}
+
+	resp, err := d.files.DownloadVersion(d.asUser(c), &gv1.VersionRequest{
+		OwnerId:   c.GetInt64("uid"),
+		FileId:    id,
+		VersionId: c.Param("version"),
+	})
+	if err != nil {
+		filesError(c, err, "download failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"url": resp.DownloadUrl, "expires_at": resp.ExpiresAt})
+}
+
+// restoreVersion makes an old version of a file the current one.
+func (d *deps) restoreVersion(c *gin.Context) {
+	id, ok := fileParam(c)
+	if !ok {
+		return
+	}
+
+	f, err := d.files.RestoreVersion(d.asUser(c), &gv1.VersionRequest{
+		OwnerId:   c.GetInt64("uid"),
+		FileId:    id,
+		VersionId: c.Param("version"),
+	})
+	if err != nil {
+		filesError(c, err, "restore failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, f)
+}
@@ -10,11 +10,10 @@ import (
 	"strings"
 	"time"
 
+	"godrive/internal/mtls"
 	gv1 "godrive/proto/godrive/v1"
 
 	"github.com/nats-io/nats.go"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // Minimal S3/MinIO event payload model.
@@ -43,10 +42,12 @@ func main() {
 	}
 	defer nc.Drain()
 
-	filesConn, err := grpc.NewClient(
-		filesAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	tlsSrc, err := mtls.Setup("ingest")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	filesConn, err := tlsSrc.Dial(filesAddr)
 	if err != nil {
 		log.Fatalf("dial files: %v", err)
 	}
@@ -118,7 +119,9 @@ func handleEvent(ctx context.Context, filesClient gv1.FilesServiceClient, data [
 	return nil
 }
 
-// Expect keys like: user/<uid>/<timestamp>_<filename>
+// Expect keys like: user/<uid>/<id>. The file's name comes from its upload
+// intent in files; older keys, user/<uid>/<timestamp>_<filename>, carry it
+// themselves and it is passed along for those.
 func parseObjectKey(key string) (ownerID int64, filename string, ok bool) {
 	parts := strings.Split(key, "/")
 	if len(parts) < 3 || parts[0] != "user" {
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"godrive/internal/audit"
+)
+
+// revocationSubject is where auth broadcasts revocations; the gateways
+// listen there.
+const revocationSubject = "godrive.auth.revoked"
+
+// revocation mirrors auth's payload for a user-wide revocation.
+type revocation struct {
+	UserID    int64     `json:"uid"`
+	NotBefore time.Time `json:"not_before"`
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// eraseAccounts carries out account deletions whose cooling-off period is
+// over (see auth's DeleteAccount). For each account it soft-deletes the
+// files, purges their objects, removes the user row (auth's tables cascade)
+// and records the erasure in account_erasures. An account with objects that
+// could not be deleted is left in place and retried on the next run.
+func (j *janitor) eraseAccounts(ctx context.Context) error {
+	rows, err := j.db.Query(ctx, `
+SELECT id, delete_after
+FROM users
+WHERE delete_after IS NOT NULL
+  AND delete_after <= now()
+ORDER BY delete_after
+LIMIT 10`)
+	if err != nil {
+		return err
+	}
+
+	type account struct {
+		id          int64
+		deleteAfter time.Time
+	}
+
+	var due []account
+	for rows.Next() {
+		var a account
+		if err := rows.Scan(&a.id, &a.deleteAfter); err != nil {
+			rows.Close()
+			return err
+		}
+		due = append(due, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, a := range due {
+		if err := j.eraseAccount(ctx, a.id, a.deleteAfter); err != nil {
+			log.Printf("erase account uid=%d: %v", a.id, err)
+		}
+	}
+
+	return nil
+}
+
+func (j *janitor) eraseAccount(ctx context.Context, uid int64, due time.Time) error {
+	// Soft-delete first so the files disappear from listings right away,
+	// even if purging their objects takes several runs.
+	if _, err := j.db.Exec(ctx,
+		`UPDATE files SET deleted_at = now() WHERE owner_id = $1 AND deleted_at IS NULL`,
+		uid,
+	); err != nil {
+		return err
+	}
+
+	// Files first: a folder's row only goes once it is empty.
+	rows, err := j.db.Query(ctx,
+		`SELECT id, object_key FROM files WHERE owner_id = $1 ORDER BY is_folder, id DESC`,
+		uid,
+	)
+	if err != nil {
+		return err
+	}
+
+	type file struct {
+		id        int64
+		objectKey string
+	}
+
+	var files []file
+	for rows.Next() {
+		var f file
+		if err := rows.Scan(&f.id, &f.objectKey); err != nil {
+			rows.Close()
+			return err
+		}
+		files = append(files, f)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	left := 0
+	for _, f := range files {
+		if !j.purgeFile(ctx, f.id, f.objectKey) {
+			left++
+		}
+	}
+	if left > 0 {
+		log.Printf("account uid=%d: %d of %d files left, retrying next run", uid, left, len(files))
+		return nil
+	}
+
+	tx, err := j.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	ct, err := tx.Exec(ctx,
+		`DELETE FROM users WHERE id = $1 AND delete_after IS NOT NULL AND delete_after <= now()`,
+		uid,
+	)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		// Another janitor replica got there first.
+		return nil
+	}
+
+	// Grants to the account go with it; those to its email stay, for
+	// whoever verifies that address next.
+	if _, err := tx.Exec(ctx, `DELETE FROM shares WHERE grantee_id = $1`, uid); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+INSERT INTO account_erasures(user_id, due_at)
+VALUES($1, $2)`, uid, due)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	j.publishRevocation(uid)
+
+	log.Printf("erased account uid=%d", uid)
+	j.audit.Record(ctx, audit.Event{
+		Action:  "janitor.account_erased",
+		Target:  fmt.Sprintf("user:%d", uid),
+		Details: map[string]string{"files": strconv.Itoa(len(files))},
+	})
+	return nil
+}
+
+// publishRevocation tells the gateways to stop honouring any token an erased
+// account was issued. Auth already refuses them (the user row is gone), and
+// revoked them when the deletion was scheduled; this covers tokens the
+// gateways verify locally. Failures are logged, as in auth.
+func (j *janitor) publishRevocation(uid int64) {
+	now := time.Now()
+	b, err := json.Marshal(revocation{UserID: uid, NotBefore: now, ExpiresAt: now.Add(j.accessTTL)})
+	if err != nil {
+		log.Printf("marshal revocation: %v", err)
+		return
+	}
+
+	if err := j.nc.Publish(revocationSubject, b); err != nil {
+		log.Printf("publish revocation uid=%d: %v", uid, err)
+	}
+}
@@ -2,21 +2,31 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"time"
 
+	"godrive/internal/audit"
+	"godrive/internal/mtls"
 	gv1 "godrive/proto/godrive/v1"
 
 	"github.com/jackc/pgx/v5/pgxpool"
-	"google.golang.org/grpc"
+	"github.com/nats-io/nats.go"
 )
 
 type janitor struct {
 	db      *pgxpool.Pool
 	storage gv1.StorageServiceClient
+	audit   *audit.Log
 	// how long we wait before hard-deleting (after user delete)
 	gracePeriod time.Duration
+	// how long a file's old versions are kept once replaced
+	versionRetention time.Duration
+	// revocations for erased accounts go to the gateways, as auth's do
+	nc *nats.Conn
+	// auth's ACCESS_TOKEN_TTL: how long such a revocation matters
+	accessTTL time.Duration
 }
 
 func main() {
@@ -29,25 +39,51 @@ func main() {
 	if err != nil {
 		log.Fatalf("invalid GRACE_PERIOD: %v", err)
 	}
+	retention, err := time.ParseDuration(env("VERSION_RETENTION", "720h"))
+	if err != nil {
+		log.Fatalf("invalid VERSION_RETENTION: %v", err)
+	}
+	accessTTL, err := time.ParseDuration(env("ACCESS_TOKEN_TTL", "15m"))
+	if err != nil {
+		log.Fatalf("invalid ACCESS_TOKEN_TTL: %v", err)
+	}
 
 	db, err := pgxpool.New(context.Background(), dsn)
 	if err != nil {
 		log.Fatalf("connect db: %v", err)
 	}
+	if err := audit.Migrate(context.Background(), db); err != nil {
+		log.Fatalf("migrate audit log: %v", err)
+	}
 
-	conn, err := grpc.Dial(storageAddr, grpc.WithInsecure())
+	tlsSrc, err := mtls.Setup("janitor")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	conn, err := tlsSrc.Dial(storageAddr)
 	if err != nil {
 		log.Fatalf("dial storage: %v", err)
 	}
 	storageClient := gv1.NewStorageServiceClient(conn)
 
+	nc, err := nats.Connect(env("NATS_URL", "nats://nats:4222"))
+	if err != nil {
+		log.Fatalf("connect NATS: %v", err)
+	}
+	defer nc.Drain()
+
 	j := &janitor{
-		db:          db,
-		storage:     storageClient,
-		gracePeriod: grace,
+		db:               db,
+		storage:          storageClient,
+		audit:            audit.New(db, "janitor"),
+		gracePeriod:      grace,
+		versionRetention: retention,
+		nc:               nc,
+		accessTTL:        accessTTL,
 	}
 
-	log.Printf("janitor started, grace period = %s", grace)
+	log.Printf("janitor started, grace period = %s, old versions kept %s", grace, retention)
 
 	// simple loop: run every minute
 	ticker := time.NewTicker(1 * time.Minute)
@@ -57,17 +93,24 @@ func main() {
 		if err := j.runOnce(context.Background()); err != nil {
 			log.Printf("janitor run failed: %v", err)
 		}
+		if err := j.sweepVersions(context.Background()); err != nil {
+			log.Printf("version sweep failed: %v", err)
+		}
+		if err := j.eraseAccounts(context.Background()); err != nil {
+			log.Printf("account erasure failed: %v", err)
+		}
 		<-ticker.C
 	}
 }
 
 func (j *janitor) runOnce(ctx context.Context) error {
-	// select candidates
+	// select candidates; files before folders, which must be empty to go
 	rows, err := j.db.Query(ctx, `
 SELECT id, object_key
 FROM files
 WHERE deleted_at IS NOT NULL
   AND deleted_at < NOW() - $1::interval
+ORDER BY is_folder, id DESC
 LIMIT 100
 `, j.gracePeriod.String())
 	if err != nil {
@@ -90,33 +133,186 @@ LIMIT 100
 	}
 
 	if len(batch) == 0 {
-		return nil
+		return j.sweepUploads(ctx)
 	}
 
 	log.Printf("janitor: found %d files to purge", len(batch))
 
 	for _, r := range batch {
-		cctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-		_, err := j.storage.DeleteObject(cctx, &gv1.DeleteObjectRequest{ObjectKey: r.objectKey})
-		cancel()
-		if err != nil {
-			log.Printf("delete object %q failed, keep row: %v", r.objectKey, err)
-			continue
+		j.purgeFile(ctx, r.id, r.objectKey)
+	}
+
+	return j.sweepUploads(ctx)
+}
+
+// uploadIntentTTL is how long an upload intent waits for its object. The
+// presigned URL is long gone by then.
+const uploadIntentTTL = 24 * time.Hour
+
+// sweepUploads forgets upload intents whose object never arrived.
+func (j *janitor) sweepUploads(ctx context.Context) error {
+	_, err := j.db.Exec(ctx,
+		`DELETE FROM uploads WHERE created_at < $1`,
+		time.Now().Add(-uploadIntentTTL),
+	)
+
+	return err
+}
+
+// deleteObject asks storage to delete one object.
+func (j *janitor) deleteObject(ctx context.Context, objectKey string) error {
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := j.storage.DeleteObject(cctx, &gv1.DeleteObjectRequest{ObjectKey: objectKey})
+	return err
+}
+
+// purgeFile deletes a file's objects from storage, then its row. It reports
+// whether both are gone; on failure the row is kept so a later run retries.
+// Folders have no object (objectKey is "") and go once they are empty.
+func (j *janitor) purgeFile(ctx context.Context, id int64, objectKey string) bool {
+	// Old versions first; the row keeps pointing at the current one.
+	if !j.purgeOldVersions(ctx, id, objectKey) {
+		return false
+	}
+
+	if objectKey != "" {
+		if err := j.deleteObject(ctx, objectKey); err != nil {
+			log.Printf("delete object %q failed, keep row: %v", objectKey, err)
+			return false
+		}
+	}
+
+	// only delete DB row if object delete succeeded
+	ct, err := j.db.Exec(ctx, `
+DELETE FROM files
+WHERE id = $1
+  AND NOT EXISTS (SELECT 1 FROM files c WHERE c.parent_id = $1)`, id)
+	if err != nil {
+		log.Printf("delete db row id=%d failed (object already gone): %v", id, err)
+		return false
+	}
+	if ct.RowsAffected() == 0 {
+		return false // a folder whose contents aren't purged yet
+	}
+
+	log.Printf("purged file id=%d key=%q", id, objectKey)
+	j.audit.Record(ctx, audit.Event{
+		Action:  "janitor.file_purged",
+		Target:  fmt.Sprintf("file:%d", id),
+		Details: map[string]string{"object_key": objectKey},
+	})
+	return true
+}
+
+// purgeOldVersions deletes every version of a file but the one whose object
+// is current.
+func (j *janitor) purgeOldVersions(ctx context.Context, fileID int64, current string) bool {
+	rows, err := j.db.Query(ctx, `
+SELECT version, object_key
+FROM file_versions
+WHERE file_id = $1
+  AND object_key <> $2`, fileID, current)
+	if err != nil {
+		log.Printf("list versions of file id=%d failed: %v", fileID, err)
+		return false
+	}
+
+	type version struct {
+		n         int
+		objectKey string
+	}
+
+	var versions []version
+	for rows.Next() {
+		var v version
+		if err := rows.Scan(&v.n, &v.objectKey); err != nil {
+			rows.Close()
+			log.Printf("list versions of file id=%d failed: %v", fileID, err)
+			return false
 		}
+		versions = append(versions, v)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Printf("list versions of file id=%d failed: %v", fileID, err)
+		return false
+	}
 
-		// only delete DB row if object delete succeeded
-		_, err = j.db.Exec(ctx, `DELETE FROM files WHERE id = $1`, r.id)
-		if err != nil {
-			log.Printf("delete db row id=%d failed (object already gone): %v", r.id, err)
-			continue
+	for _, v := range versions {
+		if !j.purgeVersion(ctx, fileID, v.n, v.objectKey) {
+			return false
 		}
+	}
 
-		log.Printf("purged file id=%d key=%q", r.id, r.objectKey)
+	return true
+}
+
+// sweepVersions deletes old versions that were replaced more than
+// versionRetention ago. Current versions are never touched.
+func (j *janitor) sweepVersions(ctx context.Context) error {
+	rows, err := j.db.Query(ctx, `
+SELECT file_id, version, object_key
+FROM file_versions
+WHERE superseded_at < $1
+ORDER BY superseded_at
+LIMIT 100`, time.Now().Add(-j.versionRetention))
+	if err != nil {
+		return err
+	}
+
+	type version struct {
+		fileID    int64
+		n         int
+		objectKey string
+	}
+
+	var batch []version
+	for rows.Next() {
+		var v version
+		if err := rows.Scan(&v.fileID, &v.n, &v.objectKey); err != nil {
+			rows.Close()
+			return err
+		}
+		batch = append(batch, v)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, v := range batch {
+		j.purgeVersion(ctx, v.fileID, v.n, v.objectKey)
 	}
 
 	return nil
 }
 
+// purgeVersion deletes one old version's object, then its row.
+func (j *janitor) purgeVersion(ctx context.Context, fileID int64, n int, objectKey string) bool {
+	if err := j.deleteObject(ctx, objectKey); err != nil {
+		log.Printf("delete object %q failed, keep version: %v", objectKey, err)
+		return false
+	}
+
+	_, err := j.db.Exec(ctx,
+		`DELETE FROM file_versions WHERE file_id = $1 AND version = $2`,
+		fileID, n,
+	)
+	if err != nil {
+		log.Printf("delete version %d of file id=%d failed (object already gone): %v", n, fileID, err)
+		return false
+	}
+
+	j.audit.Record(ctx, audit.Event{
+		Action:  "janitor.version_purged",
+		Target:  fmt.Sprintf("file:%d", fileID),
+		Details: map[string]string{"version": fmt.Sprint(n), "object_key": objectKey},
+	})
+	return true
+}
+
 func env(k, d string) string {
 	if v := os.Getenv(k); v != "" {
 		return v
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	gv1 "godrive/proto/godrive/v1"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOwnsKey(t *testing.T) {
+	tests := []struct {
+		name string
+		uid  int64
+		req  any
+		ok   bool
+	}{
+		{"own object", 5, &gv1.PresignUploadRequest{ObjectKey: "user/5/3f2a"}, true},
+		{"own nested object", 5, &gv1.PresignDownloadRequest{ObjectKey: "user/5/a/b"}, true},
+		{"other user", 5, &gv1.PresignUploadRequest{ObjectKey: "user/6/3f2a"}, false},
+		{"id prefix", 5, &gv1.PresignUploadRequest{ObjectKey: "user/55/3f2a"}, false},
+		{"longer id", 55, &gv1.PresignUploadRequest{ObjectKey: "user/5/3f2a"}, false},
+		{"leading zero", 5, &gv1.PresignUploadRequest{ObjectKey: "user/05/3f2a"}, false},
+		{"plus sign", 5, &gv1.PresignUploadRequest{ObjectKey: "user/+5/3f2a"}, false},
+		{"no slash after id", 5, &gv1.PresignUploadRequest{ObjectKey: "user/5"}, false},
+		{"other prefix", 5, &gv1.PresignUploadRequest{ObjectKey: "users/5/3f2a"}, false},
+		{"leading slash", 5, &gv1.PresignUploadRequest{ObjectKey: "/user/5/3f2a"}, false},
+		{"no key", 5, &gv1.PresignUploadRequest{}, false},
+		{"no user", 0, &gv1.PresignUploadRequest{ObjectKey: "user/0/x"}, false},
+		{"request without a key", 5, &gv1.Empty{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ownsKey(tt.uid, tt.req)
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("ownsKey(%d) = %v, want nil", tt.uid, err)
+				}
+				return
+			}
+			if status.Code(err) != codes.PermissionDenied {
+				t.Fatalf("ownsKey(%d) = %v, want PermissionDenied", tt.uid, err)
+			}
+		})
+	}
+}
+
+func TestUploadPolicy(t *testing.T) {
+	// Presigning is local signing; with the region given nothing is dialled.
+	mc, err := minio.New("minio.invalid:9000", &minio.Options{
+		Creds:  credentials.NewStaticV4("key", "secret", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, max := range []int64{1, 10 << 20} {
+		p, err := uploadPolicy("godrive", "user/5/3f2a", max, time.Now().Add(time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, fields, err := mc.PresignedPostPolicy(context.Background(), p)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		raw, err := base64.StdEncoding.DecodeString(fields["policy"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		var policy struct {
+			Conditions []json.RawMessage `json:"conditions"`
+		}
+		if err := json.Unmarshal(raw, &policy); err != nil {
+			t.Fatal(err)
+		}
+
+		var rangeOK, keyOK bool
+		for _, c := range policy.Conditions {
+			var cond []any
+			if json.Unmarshal(c, &cond) != nil || len(cond) != 3 {
+				continue
+			}
+			switch cond[0] {
+			case "content-length-range":
+				rangeOK = cond[1] == 0.0 && cond[2] == float64(max)
+			case "eq":
+				keyOK = keyOK || (cond[1] == "$key" && cond[2] == "user/5/3f2a")
+			}
+		}
+		if !rangeOK || !keyOK {
+			t.Errorf("max %d: policy %s lacks the length range or key", max, raw)
+		}
+	}
+
+	if _, err := uploadPolicy("godrive", "user/5/3f2a", -1, time.Now().Add(time.Minute)); err == nil {
+		t.Error("uploadPolicy accepted a negative size")
+	}
+}